@@ -1,16 +1,18 @@
 package qb
 
+import "strings"
+
 // NewAdapter returns a adapter pointer given driver
 func NewAdapter(driver string) Adapter {
 	switch driver {
 	case "postgres":
 		return &PostgresAdapter{escaping: false, bindingIndex: 0}
 	case "mysql":
-		return &MysqlAdapter{false}
+		return &MysqlAdapter{escaping: false}
 	case "sqlite3":
-		return &SqliteAdapter{false}
+		return &SqliteAdapter{escaping: false}
 	default:
-		return &DefaultAdapter{false}
+		return &DefaultAdapter{escaping: false}
 	}
 }
 
@@ -18,6 +20,8 @@ func NewAdapter(driver string) Adapter {
 // It is for fixing compatibility issues of different drivers
 type Adapter interface {
 	Escape(str string) string
+	QuoteIdentifier(str string) string
+	QuoteLiteral(str string) string
 	EscapeAll([]string) []string
 	SetEscaping(escaping bool)
 	Escaping() bool
@@ -27,9 +31,116 @@ type Adapter interface {
 	Reset()
 	SupportsInlinePrimaryKey() bool
 	SupportsUnsigned() bool
+	SupportsFeature(f Feature) bool
+	Version() string
+	SetVersion(version string)
 	Driver() string
 }
 
+// Feature is an sql capability that is not supported by every driver
+type Feature string
+
+// Features that builder methods check using SupportsFeature of the adapter
+const (
+	FeatureReturning           Feature = "RETURNING"
+	FeatureCTE                 Feature = "CTE"
+	FeatureMaterializedCTE     Feature = "MATERIALIZED CTE"
+	FeatureWritableCTE         Feature = "WRITABLE CTE"
+	FeatureLateral             Feature = "LATERAL"
+	FeatureWindowFunctions     Feature = "WINDOW FUNCTIONS"
+	FeatureFullOuterJoin       Feature = "FULL OUTER JOIN"
+	FeatureGroupingSets        Feature = "GROUPING SETS"
+	FeatureRollup              Feature = "ROLLUP"
+	FeatureCube                Feature = "CUBE"
+	FeatureFilter              Feature = "FILTER"
+	FeatureILike               Feature = "ILIKE"
+	FeatureAny                 Feature = "ANY / ALL"
+	FeatureTablesample         Feature = "TABLESAMPLE"
+	FeatureNullsOrdering       Feature = "NULLS FIRST / LAST"
+	FeatureFullTextIndex       Feature = "FULLTEXT INDEX"
+	FeatureIndexInclude        Feature = "INDEX INCLUDE"
+	FeatureIndexConcurrently   Feature = "CREATE INDEX CONCURRENTLY"
+	FeatureMaintenance         Feature = "MAINTENANCE COMMANDS"
+	FeatureReindexConcurrently Feature = "REINDEX CONCURRENTLY"
+	FeatureSessionConfig       Feature = "SET CONFIGURATION"
+	FeatureSchemas             Feature = "SCHEMAS"
+	FeaturePrivileges          Feature = "GRANT / REVOKE"
+	FeatureRoles               Feature = "ROLES"
+	FeatureComments            Feature = "COMMENT ON"
+	FeatureAlterColumn         Feature = "ALTER COLUMN"
+	FeatureRenameIndex         Feature = "RENAME INDEX"
+	FeatureMaterializedView    Feature = "MATERIALIZED VIEW"
+	FeatureFunctions           Feature = "FUNCTIONS"
+	FeatureTriggers            Feature = "TRIGGERS"
+	FeatureExecuteFunction     Feature = "EXECUTE FUNCTION"
+	FeatureListenNotify        Feature = "LISTEN / NOTIFY"
+	FeatureLockTable           Feature = "LOCK TABLE"
+	FeatureSetConstraints      Feature = "SET CONSTRAINTS"
+	FeatureDo                  Feature = "DO"
+	FeaturePrepare             Feature = "PREPARE"
+	FeatureLoadData            Feature = "LOAD DATA"
+	FeatureRowLevelSecurity    Feature = "ROW LEVEL SECURITY"
+	FeatureInheritance         Feature = "TABLE INHERITANCE"
+	FeaturePartitioning        Feature = "DECLARATIVE PARTITIONING"
+	FeatureUnlogged            Feature = "UNLOGGED TABLES"
+	FeatureExtensions          Feature = "EXTENSIONS"
+	FeatureCollations          Feature = "COLLATIONS"
+	FeatureLogicalReplication  Feature = "LOGICAL REPLICATION"
+	FeatureInformationSchema   Feature = "INFORMATION SCHEMA"
+	FeatureUUID                Feature = "UUID GENERATION"
+	FeatureGenRandomUUID       Feature = "GEN_RANDOM_UUID"
+	FeatureJSONB               Feature = "JSONB"
+	FeatureArrays              Feature = "ARRAY COLUMNS"
+	FeatureSpatial             Feature = "SPATIAL TYPES"
+	FeatureVector              Feature = "VECTOR"
+	FeatureIdentityColumns     Feature = "IDENTITY COLUMNS"
+	FeaturePolicyType          Feature = "POLICY TYPES"
+)
+
+// common feature support given the minimum versions of features
+// empty version is assumed to be the latest version of the driver
+func supportsFeature(features map[Feature]string, version string, f Feature) bool {
+	min, ok := features[f]
+	if !ok {
+		return false
+	}
+	return version == "" || compareVersions(version, min) >= 0
+}
+
+// compareVersions compares dotted numeric versions such as "9.6" and "10.1"
+// non numeric suffixes like "8.0.32-log" are ignored
+func compareVersions(v1 string, v2 string) int {
+	p1 := strings.Split(v1, ".")
+	p2 := strings.Split(v2, ".")
+	for i := 0; i < len(p1) || i < len(p2); i++ {
+		n1, n2 := 0, 0
+		if i < len(p1) {
+			n1 = versionNumber(p1[i])
+		}
+		if i < len(p2) {
+			n2 = versionNumber(p2[i])
+		}
+		if n1 != n2 {
+			if n1 < n2 {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionNumber(piece string) int {
+	n := 0
+	for _, c := range piece {
+		if c < '0' || c > '9' {
+			break
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
 // common escape all
 func escapeAll(adapter Adapter, strings []string) []string {
 	for k, v := range strings {
@@ -23,8 +23,13 @@ func (suite *AdapterTestSuite) SetupTest() {
 
 func (suite *AdapterTestSuite) TestDefaultAdapter() {
 	assert.Equal(suite.T(), suite.def.SupportsUnsigned(), false)
+	assert.Equal(suite.T(), suite.def.SupportsFeature(FeatureCTE), false)
+	suite.def.SetVersion("1.0")
+	assert.Equal(suite.T(), suite.def.Version(), "1.0")
 	assert.Equal(suite.T(), suite.def.AutoIncrement(), "AUTO INCREMENT")
 	assert.Equal(suite.T(), suite.def.Escape("test"), "test")
+	assert.Equal(suite.T(), suite.def.QuoteIdentifier("test"), "`test`")
+	assert.Equal(suite.T(), suite.def.QuoteLiteral("it's"), "'it''s'")
 	assert.Equal(suite.T(), suite.def.Escaping(), false)
 	suite.def.SetEscaping(true)
 	assert.Equal(suite.T(), suite.def.Escaping(), true)
@@ -39,8 +44,19 @@ func (suite *AdapterTestSuite) TestDefaultAdapter() {
 
 func (suite *AdapterTestSuite) TestMysqlAdapter() {
 	assert.Equal(suite.T(), suite.mysql.SupportsUnsigned(), true)
+	assert.Equal(suite.T(), suite.mysql.SupportsFeature(FeatureReturning), false)
+	suite.mysql.SetVersion("5.7.22-log")
+	assert.Equal(suite.T(), suite.mysql.SupportsFeature(FeatureWindowFunctions), false)
+	suite.mysql.SetVersion("8.0.32-log")
+	assert.Equal(suite.T(), suite.mysql.SupportsFeature(FeatureWindowFunctions), true)
+	assert.Equal(suite.T(), suite.mysql.Version(), "8.0.32-log")
+	assert.Equal(suite.T(), suite.mysql.SupportsFeature(FeatureRollup), true)
 	assert.Equal(suite.T(), suite.mysql.AutoIncrement(), "AUTO_INCREMENT")
 	assert.Equal(suite.T(), suite.mysql.Escape("test"), "test")
+	assert.Equal(suite.T(), suite.mysql.QuoteIdentifier("test"), "`test`")
+	assert.Equal(suite.T(), suite.mysql.QuoteIdentifier("te`st"), "`te``st`")
+	assert.Equal(suite.T(), suite.mysql.QuoteLiteral("it's \\n"), "'it''s \\\\n'")
+	assert.Equal(suite.T(), suite.mysql.QuoteLiteral("x\\' OR 1=1 -- "), "'x\\\\'' OR 1=1 -- '")
 	assert.Equal(suite.T(), suite.mysql.Escaping(), false)
 	suite.mysql.SetEscaping(true)
 	assert.Equal(suite.T(), suite.mysql.Escaping(), true)
@@ -55,8 +71,18 @@ func (suite *AdapterTestSuite) TestMysqlAdapter() {
 
 func (suite *AdapterTestSuite) TestPostgresAdapter() {
 	assert.Equal(suite.T(), suite.postgres.SupportsUnsigned(), false)
+	assert.Equal(suite.T(), suite.postgres.SupportsFeature(FeatureGroupingSets), true)
+	assert.Equal(suite.T(), suite.postgres.Version(), "")
+	suite.postgres.SetVersion("11.4")
+	assert.Equal(suite.T(), suite.postgres.Version(), "11.4")
+	assert.Equal(suite.T(), suite.postgres.SupportsFeature(FeatureMaterializedCTE), false)
+	suite.postgres.SetVersion("12.1")
+	assert.Equal(suite.T(), suite.postgres.SupportsFeature(FeatureMaterializedCTE), true)
 	assert.Equal(suite.T(), suite.postgres.AutoIncrement(), "")
 	assert.Equal(suite.T(), suite.postgres.Escape("test"), "test")
+	assert.Equal(suite.T(), suite.postgres.QuoteIdentifier("test"), "\"test\"")
+	assert.Equal(suite.T(), suite.postgres.QuoteIdentifier("te\"st"), "\"te\"\"st\"")
+	assert.Equal(suite.T(), suite.postgres.QuoteLiteral("it's"), "'it''s'")
 	assert.Equal(suite.T(), suite.postgres.Escaping(), false)
 	suite.postgres.SetEscaping(true)
 	assert.Equal(suite.T(), suite.postgres.Escaping(), true)
@@ -73,8 +99,12 @@ func (suite *AdapterTestSuite) TestPostgresAdapter() {
 
 func (suite *AdapterTestSuite) TestSqliteAdapter() {
 	assert.Equal(suite.T(), suite.sqlite.SupportsUnsigned(), false)
+	assert.Equal(suite.T(), suite.sqlite.SupportsFeature(FeatureCube), false)
+	assert.Equal(suite.T(), suite.sqlite.SupportsFeature(FeatureFilter), true)
 	assert.Equal(suite.T(), suite.sqlite.AutoIncrement(), "AUTOINCREMENT")
 	assert.Equal(suite.T(), suite.sqlite.Escape("test"), "test")
+	assert.Equal(suite.T(), suite.sqlite.QuoteIdentifier("test"), "`test`")
+	assert.Equal(suite.T(), suite.sqlite.QuoteLiteral("it's"), "'it''s'")
 	assert.Equal(suite.T(), suite.sqlite.Escaping(), false)
 	suite.sqlite.SetEscaping(true)
 	assert.Equal(suite.T(), suite.sqlite.Escaping(), true)
@@ -87,6 +117,12 @@ func (suite *AdapterTestSuite) TestSqliteAdapter() {
 	suite.sqlite.Reset() // does nothing
 }
 
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, compareVersions("9.6", "10"), -1)
+	assert.Equal(t, compareVersions("10.1", "10.1.0"), 0)
+	assert.Equal(t, compareVersions("8.0.32-log", "8.0.14"), 1)
+}
+
 func TestAdapterTestSuite(t *testing.T) {
 	suite.Run(t, new(AdapterTestSuite))
 }
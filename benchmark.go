@@ -0,0 +1,42 @@
+package qb
+
+import (
+	"runtime"
+	"time"
+)
+
+// BenchmarkResult is the result of a builder benchmark
+type BenchmarkResult struct {
+	TotalNs            int64
+	PerIterationNs     int64
+	AllocsPerIteration int64
+}
+
+// BenchmarkBuilder runs fn n times against a builder of driver that is reset after each iteration
+// and returns the elapsed time and the number of allocations per iteration
+func BenchmarkBuilder(driver string, fn func(*Builder), n int) BenchmarkResult {
+	if n <= 0 {
+		return BenchmarkResult{}
+	}
+
+	b := NewBuilder(driver)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i := 0; i < n; i++ {
+		fn(b)
+		b.Reset()
+	}
+
+	total := time.Since(start).Nanoseconds()
+	runtime.ReadMemStats(&after)
+
+	return BenchmarkResult{
+		TotalNs:            total,
+		PerIterationNs:     total / int64(n),
+		AllocsPerIteration: int64(after.Mallocs-before.Mallocs) / int64(n),
+	}
+}
@@ -0,0 +1,22 @@
+package qb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBenchmarkBuilder(t *testing.T) {
+	calls := 0
+	result := BenchmarkBuilder("postgres", func(b *Builder) {
+		calls++
+		b.Select("id").From("user").Where(b.Eq("id", calls))
+		assert.Equal(t, b.BindingCount(), 1)
+	}, 100)
+
+	assert.Equal(t, calls, 100)
+	assert.True(t, result.TotalNs > 0)
+	assert.Equal(t, result.PerIterationNs, result.TotalNs/100)
+	assert.True(t, result.AllocsPerIteration > 0)
+
+	assert.Equal(t, BenchmarkBuilder("postgres", func(b *Builder) {}, 0), BenchmarkResult{})
+}
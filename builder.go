@@ -2,9 +2,14 @@ package qb
 
 import (
 	"fmt"
+	"github.com/lib/pq"
 	"log"
+	"math"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -13,25 +18,87 @@ const (
 	LQuery
 	// log bindings flag
 	LBindings
+	// log timing flag of tracers
+	LTiming = 4
 )
 
+// BuilderOptions is the set of options to configure a builder
+type BuilderOptions struct {
+	// Driver is one of postgres, mysql or sqlite3
+	Driver string
+
+	// Escaping enables escaping of identifiers
+	Escaping bool
+
+	// LogFlags are the log flags of the builder
+	LogFlags int
+
+	// Logger is the logger of the builder, defaults to a stdout logger
+	Logger *log.Logger
+
+	// TablePrefix is prepended to every table name
+	TablePrefix string
+
+	// MaxBindings is the maximum number of bindings of a query, zero means unlimited
+	MaxBindings int
+}
+
 // NewBuilder generates a new builder struct
 func NewBuilder(driver string) *Builder {
+	return newBuilder(BuilderOptions{Driver: driver})
+}
+
+// NewBuilderWithOptions validates the options and generates a new builder struct
+func NewBuilderWithOptions(opts BuilderOptions) (*Builder, error) {
+	switch opts.Driver {
+	case "postgres", "mysql", "sqlite3":
+	default:
+		return nil, fmt.Errorf("Invalid driver %s", opts.Driver)
+	}
+
+	if opts.MaxBindings < 0 {
+		return nil, fmt.Errorf("Invalid max bindings %d", opts.MaxBindings)
+	}
+
+	return newBuilder(opts), nil
+}
+
+func newBuilder(opts BuilderOptions) *Builder {
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.New(os.Stdout, "", 0)
+	}
+
+	adapter := NewAdapter(opts.Driver)
+	adapter.SetEscaping(opts.Escaping)
+
 	return &Builder{
-		query:    NewQuery(),
-		adapter:  NewAdapter(driver),
-		logger:   log.New(os.Stdout, "", 0),
-		logFlags: LDefault,
+		query:       NewQuery(),
+		adapter:     adapter,
+		logger:      logger,
+		logFlags:    opts.LogFlags,
+		ctes:        []string{},
+		tablePrefix: opts.TablePrefix,
+		maxBindings: opts.MaxBindings,
 	}
 }
 
 // Builder is a struct that holds an active query that it is used for building common sql queries
 // it has all the common functions except multiple statements & table crudders
 type Builder struct {
-	query    *Query
-	adapter  Adapter
-	logger   *log.Logger
-	logFlags int
+	query       *Query
+	adapter     Adapter
+	logger      *log.Logger
+	logFlags    int
+	ctes        []string
+	whereOp     string
+	whereClause string
+	tablePrefix string
+	maxBindings int
+	maxLength   int
+	timeout     time.Duration
+	timeoutMode TimeoutMode
+	queryHook   func(*Query)
 }
 
 // SetLogFlags sets the builder log flags
@@ -44,6 +111,100 @@ func (b *Builder) LogFlags() int {
 	return b.logFlags
 }
 
+// SetMaxQueryLength sets the max length of generated sql in bytes
+// If the sql of a query exceeds n bytes, an error is added to the query. Zero disables the check
+func (b *Builder) SetMaxQueryLength(n int) *Builder {
+	b.maxLength = n
+	return b
+}
+
+// TimeoutMode is the way query timeouts are applied
+type TimeoutMode int
+
+// Timeout modes
+const (
+	// TimeoutHint embeds the timeout as "max_execution_time" optimizer hint into select statements.
+	// Only supported by mysql
+	TimeoutHint TimeoutMode = iota
+	// TimeoutSetLocal leaves queries untouched, the timeout is set by the query of TimeoutQuery()
+	// which should be executed in the same transaction. Only supported by postgres
+	TimeoutSetLocal
+)
+
+// SetQueryTimeout sets the statement timeout that is applied to the queries generated by Query()
+// The mode defaults to TimeoutHint for mysql and TimeoutSetLocal for other drivers. Zero disables the timeout.
+// If the mode is not supported by the driver, the timeout is disabled and an error is added to the active query
+func (b *Builder) SetQueryTimeout(d time.Duration, mode ...TimeoutMode) *Builder {
+	b.timeout = 0
+	b.timeoutMode = TimeoutSetLocal
+	if b.adapter.Driver() == "mysql" {
+		b.timeoutMode = TimeoutHint
+	}
+	if len(mode) > 0 {
+		b.timeoutMode = mode[0]
+	}
+	if d <= 0 {
+		return b
+	}
+
+	switch {
+	case b.timeoutMode == TimeoutHint && b.adapter.Driver() != "mysql":
+		b.query.AddError(fmt.Errorf("Timeout hints are not supported by %s driver", b.adapter.Driver()))
+	case b.timeoutMode == TimeoutSetLocal && !b.adapter.SupportsFeature(FeatureSessionConfig):
+		b.query.AddError(fmt.Errorf("%s is not supported by %s driver", FeatureSessionConfig, b.adapter.Driver()))
+	default:
+		b.timeout = d
+	}
+	return b
+}
+
+// TimeoutQuery returns "set local statement_timeout = %d" query of the timeout in TimeoutSetLocal mode
+// It must be executed inside the transaction of the queries since set local has no effect outside of transactions.
+// An empty query is returned for other modes or if the timeout is disabled
+func (b *Builder) TimeoutQuery() *Query {
+	query := NewQuery()
+	if b.timeout > 0 && b.timeoutMode == TimeoutSetLocal {
+		query.AddClause(fmt.Sprintf("SET LOCAL statement_timeout = %d", int64(b.timeout/time.Millisecond)))
+	}
+	return query
+}
+
+// applyTimeout embeds the statement timeout of the builder into the first select statement of query in TimeoutHint mode
+func (b *Builder) applyTimeout(query *Query) {
+	if b.timeoutMode != TimeoutHint {
+		return
+	}
+	ms := int64(b.timeout / time.Millisecond)
+	for k, c := range query.clauses {
+		if strings.HasPrefix(c, "SELECT ") {
+			query.clauses[k] = fmt.Sprintf("SELECT /*+ MAX_EXECUTION_TIME(%d) */ %s", ms, strings.TrimPrefix(c, "SELECT "))
+			return
+		}
+	}
+}
+
+// Tracer starts a timer and returns a function that logs the elapsed time with name when it is called
+// The elapsed time is logged only if log flags include LTiming
+func (b *Builder) Tracer(name string) func() {
+	start := time.Now()
+	return func() {
+		if b.logFlags&LTiming != 0 {
+			b.logger.Printf("%s took %s", name, time.Since(start))
+		}
+	}
+}
+
+// SetQueryHook sets the function that is called with every query returned by Query()
+// Passing nil removes the hook
+func (b *Builder) SetQueryHook(hook func(*Query)) {
+	b.queryHook = hook
+}
+
+// TablePrefix returns the prefix of table names
+func (b *Builder) TablePrefix() string {
+	return b.tablePrefix
+}
+
 // SetEscaping sets the escaping parameter of current adapter
 func (b *Builder) SetEscaping(escaping bool) {
 	b.adapter.SetEscaping(escaping)
@@ -62,6 +223,9 @@ func (b *Builder) Adapter() Adapter {
 // Reset clears query bindings and its errors
 func (b *Builder) Reset() {
 	b.query = NewQuery()
+	b.ctes = []string{}
+	b.whereOp = ""
+	b.whereClause = ""
 	b.adapter.Reset()
 }
 
@@ -69,22 +233,145 @@ func (b *Builder) Reset() {
 // The query clauses and returns the sql and bindings
 func (b *Builder) Query() *Query {
 	query := b.query
+	if b.timeout > 0 && len(query.clauses) > 0 {
+		b.applyTimeout(query)
+	}
+	if b.maxBindings > 0 && len(query.Bindings()) > b.maxBindings {
+		query.AddError(fmt.Errorf("Query has %d bindings, max bindings is %d", len(query.Bindings()), b.maxBindings))
+	}
+	if b.maxLength > 0 && len(query.SQL()) > b.maxLength {
+		query.AddError(fmt.Errorf("Query has %d bytes, max query length is %d", len(query.SQL()), b.maxLength))
+	}
 	b.Reset()
-	if b.logFlags == LQuery || b.logFlags == (LQuery|LBindings) {
+	if b.queryHook != nil {
+		b.queryHook(query)
+	}
+	if b.logFlags&LQuery != 0 {
 		b.logger.Printf("%s", query.SQL())
 	}
-	if b.logFlags == LBindings || b.logFlags == (LQuery|LBindings) {
+	if b.logFlags&LBindings != 0 {
 		b.logger.Printf("%s", query.Bindings())
 	}
-	if b.logFlags != LDefault {
+	if b.logFlags&(LQuery|LBindings) != 0 {
 		b.logger.Println()
 	}
 	return query
 }
 
+// DebugString returns the clauses of the active query as "[n] clause" lines followed by its bindings
+// Unlike Query, it doesn't reset the builder
+func (b *Builder) DebugString() string {
+	lines := []string{}
+	for k, c := range b.query.Clauses() {
+		lines = append(lines, fmt.Sprintf("[%d] %s", k, c))
+	}
+	lines = append(lines, fmt.Sprintf("bindings: %v", b.query.Bindings()))
+	return strings.Join(lines, "\n")
+}
+
+// ClauseCount returns the number of clauses of the active query
+func (b *Builder) ClauseCount() int {
+	return len(b.query.Clauses())
+}
+
+// HasClause returns true if any clause of the active query starts with prefix such as "WHERE" or "ORDER BY"
+func (b *Builder) HasClause(prefix string) bool {
+	for _, c := range b.query.Clauses() {
+		if strings.HasPrefix(c, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BindingCount returns the number of bindings of the active query
+// For postgres, the next placeholder is "$%d" of BindingCount() + 1
+func (b *Builder) BindingCount() int {
+	return len(b.query.Bindings())
+}
+
+// table prefixes and escapes the table name, keeping the schema and the alias if exist
+// such as "schema.table alias"
+func (b *Builder) table(table string) string {
+	tablePieces := strings.SplitN(table, " ", 2)
+
+	namePieces := strings.Split(tablePieces[0], ".")
+	namePieces[len(namePieces)-1] = fmt.Sprintf("%s%s", b.tablePrefix, namePieces[len(namePieces)-1])
+
+	v := strings.Join(b.adapter.EscapeAll(namePieces), ".")
+	if len(tablePieces) > 1 {
+		v = fmt.Sprintf("%s %s", v, tablePieces[1])
+	}
+	return v
+}
+
+// qualifiedName escapes each piece of a dot separated name such as "schema.table"
+// Star pieces such as "db.*" are left untouched
+func (b *Builder) qualifiedName(name string) string {
+	pieces := strings.Split(name, ".")
+	for k, p := range pieces {
+		if p != "*" {
+			pieces[k] = b.adapter.Escape(p)
+		}
+	}
+	return strings.Join(pieces, ".")
+}
+
+// key escapes the key unless it is a star or an sql expression such as a cast or a function call
+// Qualified keys such as "u.id" are escaped piece by piece
+func (b *Builder) key(key string) string {
+	if key == "*" || strings.ContainsAny(key, "( ") {
+		return key
+	}
+	return b.qualifiedName(key)
+}
+
+// supports returns whether the adapter supports the feature and adds an error to the active query if it doesn't
+func (b *Builder) supports(f Feature) bool {
+	if b.adapter.SupportsFeature(f) {
+		return true
+	}
+	b.query.AddError(fmt.Errorf("%s is not supported by %s driver", f, b.adapter.Driver()))
+	return false
+}
+
+// Interpolate rewrites ":name" tokens of sql to the placeholders of the adapter
+// and returns the rewritten sql with the bindings of params in placeholder order.
+// Postgres style "::type" casts and quoted string literals are left untouched
+// Placeholders are numbered from scratch so that the binding index of the current query is not affected
+func (b *Builder) Interpolate(sql string, params map[string]interface{}) (string, []interface{}, error) {
+	adapter := NewAdapter(b.adapter.Driver())
+	tokens := namedParamRegexp
+	if adapter.Driver() == "mysql" {
+		tokens = mysqlNamedParamRegexp
+	}
+	bindings := []interface{}{}
+	var err error
+	sql = tokens.ReplaceAllStringFunc(sql, func(token string) string {
+		if strings.HasPrefix(token, "::") || strings.HasPrefix(token, "'") {
+			return token
+		}
+		v, ok := params[token[1:]]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("Missing parameter %s", token)
+			}
+			return token
+		}
+		bindings = append(bindings, v)
+		return adapter.Placeholder()
+	})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql, bindings, nil
+}
+
 // Insert generates an "insert into %s(%s)" statement
 func (b *Builder) Insert(table string) *Builder {
-	clause := fmt.Sprintf("INSERT INTO %s", b.adapter.Escape(table))
+	clause := fmt.Sprintf("INSERT INTO %s", b.table(table))
 	b.query.AddClause(clause)
 	return b
 }
@@ -111,9 +398,12 @@ func (b *Builder) Values(m map[string]interface{}) *Builder {
 	return b
 }
 
-// Returning generates RETURNING statement for postgres only
-// NOTE: Do not use it with sqlite, mysql or other drivers
+// Returning generates "returning %s" statement
+// NOTE: Only supported by postgres and sqlite 3.35+
 func (b *Builder) Returning(cols ...string) *Builder {
+	if !b.supports(FeatureReturning) {
+		return b
+	}
 	cols = b.adapter.EscapeAll(cols)
 	clause := fmt.Sprintf("RETURNING %s", strings.Join(cols, ", "))
 	b.query.AddClause(clause)
@@ -122,7 +412,7 @@ func (b *Builder) Returning(cols ...string) *Builder {
 
 // Update generates "update %s" statement
 func (b *Builder) Update(table string) *Builder {
-	clause := fmt.Sprintf("UPDATE %s", b.adapter.Escape(table))
+	clause := fmt.Sprintf("UPDATE %s", b.table(table))
 	b.query.AddClause(clause)
 	return b
 }
@@ -148,7 +438,83 @@ func (b *Builder) Set(m map[string]interface{}) *Builder {
 
 // Delete generates "delete" statement
 func (b *Builder) Delete(table string) *Builder {
-	b.query.AddClause(fmt.Sprintf("DELETE FROM %s", b.adapter.Escape(table)))
+	b.query.AddClause(fmt.Sprintf("DELETE FROM %s", b.table(table)))
+	return b
+}
+
+var placeholderRegexp = regexp.MustCompile(`\$(\d+)`)
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// namedParamRegexp matches string literals as well as named params so that params inside literals are skipped
+var namedParamRegexp = regexp.MustCompile(`'(?:[^']|'')*'|::?[a-zA-Z_][a-zA-Z0-9_]*`)
+
+// mysqlNamedParamRegexp is namedParamRegexp allowing backslash escapes in string literals
+var mysqlNamedParamRegexp = regexp.MustCompile(`'(?:[^'\\]|''|\\.)*'|::?[a-zA-Z_][a-zA-Z0-9_]*`)
+
+// With generates "with %s as (%s)" statement using the query of the inner builder
+// Multiple calls accumulate the common table expressions as "with %s as (%s), %s as (%s)"
+// NOTE: With should be called before any other clause of the active query
+func (b *Builder) With(name string, inner *Builder) *Builder {
+	if !b.supports(FeatureCTE) {
+		return b
+	}
+	return b.with(name, "AS", inner)
+}
+
+// WithMaterialized generates "with %s as materialized (%s)" statement using the query of the inner builder
+// NOTE: Only supported by postgres 12+
+func (b *Builder) WithMaterialized(name string, inner *Builder) *Builder {
+	if !b.supports(FeatureMaterializedCTE) {
+		return b
+	}
+	return b.with(name, "AS MATERIALIZED", inner)
+}
+
+// WithNotMaterialized generates "with %s as not materialized (%s)" statement using the query of the inner builder
+// NOTE: Only supported by postgres 12+
+func (b *Builder) WithNotMaterialized(name string, inner *Builder) *Builder {
+	if !b.supports(FeatureMaterializedCTE) {
+		return b
+	}
+	return b.with(name, "AS NOT MATERIALIZED", inner)
+}
+
+// WritableCTE generates "with %s as (%s)" statement using the insert, update or delete query of the dml builder
+// NOTE: Only supported by postgres
+func (b *Builder) WritableCTE(name string, dml *Builder) *Builder {
+	if !b.supports(FeatureWritableCTE) {
+		return b
+	}
+	return b.with(name, "AS", dml)
+}
+
+func (b *Builder) with(name string, as string, inner *Builder) *Builder {
+	query := inner.Query()
+	for _, err := range query.Errors() {
+		b.query.AddError(err)
+	}
+	sql := strings.TrimSuffix(query.SQL(), ";")
+
+	// shift postgres placeholders of inner query by the number of bindings of previous expressions
+	if b.adapter.Driver() == "postgres" {
+		offset := len(b.query.Bindings())
+		sql = placeholderRegexp.ReplaceAllStringFunc(sql, func(p string) string {
+			n, _ := strconv.Atoi(p[1:])
+			return fmt.Sprintf("$%d", n+offset)
+		})
+	}
+	b.adapter.Placeholders(query.Bindings()...)
+	b.query.AddBinding(query.Bindings()...)
+
+	b.ctes = append(b.ctes, fmt.Sprintf("%s %s (%s)", b.adapter.Escape(name), as, sql))
+
+	clause := fmt.Sprintf("WITH %s", strings.Join(b.ctes, ", "))
+	if len(b.ctes) > 1 {
+		b.query.clauses[0] = clause
+	} else {
+		b.query.clauses = append([]string{clause}, b.query.clauses...)
+	}
 	return b
 }
 
@@ -159,80 +525,117 @@ func (b *Builder) Select(columns ...string) *Builder {
 	return b
 }
 
+// SelectRaw appends raw sql expressions to the select clause without escaping
+// If there is no select clause, it generates "select %s" statement
+func (b *Builder) SelectRaw(expressions ...string) *Builder {
+	for k := len(b.query.clauses) - 1; k >= 0; k-- {
+		if strings.HasPrefix(b.query.clauses[k], "SELECT ") {
+			b.query.clauses[k] = fmt.Sprintf("%s, %s", b.query.clauses[k], strings.Join(expressions, ", "))
+			return b
+		}
+	}
+	b.query.AddClause(fmt.Sprintf("SELECT %s", strings.Join(expressions, ", ")))
+	return b
+}
+
+// SelectCount generates "select count(%s)" statement for column
+func (b *Builder) SelectCount(column string) *Builder {
+	return b.Select(b.Count(column))
+}
+
+// SelectSum generates "select sum(%s)" statement for column
+func (b *Builder) SelectSum(column string) *Builder {
+	return b.Select(b.Sum(column))
+}
+
+// SelectAvg generates "select avg(%s)" statement for column
+func (b *Builder) SelectAvg(column string) *Builder {
+	return b.Select(b.Avg(column))
+}
+
+// SelectMin generates "select min(%s)" statement for column
+func (b *Builder) SelectMin(column string) *Builder {
+	return b.Select(b.Min(column))
+}
+
+// SelectMax generates "select max(%s)" statement for column
+func (b *Builder) SelectMax(column string) *Builder {
+	return b.Select(b.Max(column))
+}
+
 // From generates "from %s" statement for each table name
 func (b *Builder) From(tables ...string) *Builder {
 	tbls := []string{}
 	for _, v := range tables {
-		tablePieces := strings.Split(v, " ")
-		v = b.adapter.Escape(tablePieces[0])
-		if len(tablePieces) > 1 {
-			v = fmt.Sprintf("%s %s", v, tablePieces[1])
-		}
-		tbls = append(tbls, v)
+		tbls = append(tbls, b.table(v))
 	}
 	b.query.AddClause(fmt.Sprintf("FROM %s", strings.Join(tbls, ", ")))
 	return b
 }
 
+// Tablesample adds "tablesample %s(%s)" to the most recent table reference
+// which is either the last table of the from clause or the table of the last join clause
+// NOTE: Only supported by postgres
+func (b *Builder) Tablesample(method string, percent float64) *Builder {
+	if !b.supports(FeatureTablesample) {
+		return b
+	}
+	sample := fmt.Sprintf("TABLESAMPLE %s(%s)", method, strconv.FormatFloat(percent, 'f', -1, 64))
+	for k := len(b.query.clauses) - 1; k >= 0; k-- {
+		clause := b.query.clauses[k]
+		switch {
+		case strings.HasPrefix(clause, "FROM "), strings.HasPrefix(clause, "CROSS JOIN "):
+			b.query.clauses[k] = fmt.Sprintf("%s %s", clause, sample)
+			return b
+		case strings.HasPrefix(clause, "INNER JOIN "), strings.HasPrefix(clause, "LEFT OUTER JOIN "),
+			strings.HasPrefix(clause, "RIGHT OUTER JOIN "), strings.HasPrefix(clause, "FULL OUTER JOIN "):
+			on := strings.Index(clause, " ON ")
+			b.query.clauses[k] = fmt.Sprintf("%s %s%s", clause[:on], sample, clause[on:])
+			return b
+		}
+	}
+	b.query.AddError(fmt.Errorf("Tablesample requires a from clause"))
+	return b
+}
+
 // InnerJoin generates "inner join %s on %s" statement for each expression
 func (b *Builder) InnerJoin(table string, expressions ...string) *Builder {
-	tablePieces := strings.Split(table, " ")
-
-	v := b.adapter.Escape(tablePieces[0])
-	if len(tablePieces) > 1 {
-		v = fmt.Sprintf("%s %s", v, tablePieces[1])
-	}
-	b.query.AddClause(fmt.Sprintf("INNER JOIN %s ON %s", v, strings.Join(expressions, " ")))
+	b.query.AddClause(fmt.Sprintf("INNER JOIN %s ON %s", b.table(table), strings.Join(expressions, " ")))
 	return b
 }
 
+// SelfJoin generates "from %s %s inner join %s %s on %s" statement joining the table with itself
+func (b *Builder) SelfJoin(table string, alias1 string, alias2 string, expression string) *Builder {
+	return b.
+		From(fmt.Sprintf("%s %s", table, alias1)).
+		InnerJoin(fmt.Sprintf("%s %s", table, alias2), expression)
+}
+
 // CrossJoin generates "cross join %s" statement for table
 func (b *Builder) CrossJoin(table string) *Builder {
-	tablePieces := strings.Split(table, " ")
-
-	v := b.adapter.Escape(tablePieces[0])
-	if len(tablePieces) > 1 {
-		v = fmt.Sprintf("%s %s", v, tablePieces[1])
-	}
-
-	b.query.AddClause(fmt.Sprintf("CROSS JOIN %s", v))
+	b.query.AddClause(fmt.Sprintf("CROSS JOIN %s", b.table(table)))
 	return b
 }
 
 // LeftOuterJoin generates "left outer join %s on %s" statement for each expression
 func (b *Builder) LeftOuterJoin(table string, expressions ...string) *Builder {
-	tablePieces := strings.Split(table, " ")
-
-	v := b.adapter.Escape(tablePieces[0])
-	if len(tablePieces) > 1 {
-		v = fmt.Sprintf("%s %s", v, tablePieces[1])
-	}
-
-	b.query.AddClause(fmt.Sprintf("LEFT OUTER JOIN %s ON %s", v, strings.Join(expressions, " ")))
+	b.query.AddClause(fmt.Sprintf("LEFT OUTER JOIN %s ON %s", b.table(table), strings.Join(expressions, " ")))
 	return b
 }
 
 // RightOuterJoin generates "right outer join %s on %s" statement for each expression
 func (b *Builder) RightOuterJoin(table string, expressions ...string) *Builder {
-	tablePieces := strings.Split(table, " ")
-
-	v := b.adapter.Escape(tablePieces[0])
-	if len(tablePieces) > 1 {
-		v = fmt.Sprintf("%s %s", v, tablePieces[1])
-	}
-	b.query.AddClause(fmt.Sprintf("RIGHT OUTER JOIN %s ON %s", v, strings.Join(expressions, " ")))
+	b.query.AddClause(fmt.Sprintf("RIGHT OUTER JOIN %s ON %s", b.table(table), strings.Join(expressions, " ")))
 	return b
 }
 
 // FullOuterJoin generates "full outer join %s on %s" for each expression
+// NOTE: Only supported by postgres and sqlite 3.39+
 func (b *Builder) FullOuterJoin(table string, expressions ...string) *Builder {
-	tablePieces := strings.Split(table, " ")
-
-	v := b.adapter.Escape(tablePieces[0])
-	if len(tablePieces) > 1 {
-		v = fmt.Sprintf("%s %s", v, tablePieces[1])
+	if !b.supports(FeatureFullOuterJoin) {
+		return b
 	}
-	b.query.AddClause(fmt.Sprintf("FULL OUTER JOIN %s ON %s", v, strings.Join(expressions, " ")))
+	b.query.AddClause(fmt.Sprintf("FULL OUTER JOIN %s ON %s", b.table(table), strings.Join(expressions, " ")))
 	return b
 }
 
@@ -246,131 +649,654 @@ func (b *Builder) Where(expression string, bindings ...interface{}) *Builder {
 	return b
 }
 
-// OrderBy generates "order by %s" for each expression
-func (b *Builder) OrderBy(expressions ...string) *Builder {
-	b.query.AddClause(fmt.Sprintf("ORDER BY %s", strings.Join(expressions, ", ")))
-	return b
+// AndWhere generates "where (%s) and (%s)" combining the existing where clause with the expression
+// and adds bindings for each value. If there is no where clause, it behaves like Where
+// NOTE: Bindings are appended, therefore clauses with bindings after where should be added later
+func (b *Builder) AndWhere(expression string, bindings ...interface{}) *Builder {
+	return b.combineWhere("AND", expression, bindings...)
 }
 
-// GroupBy generates "group by %s" for each column
-func (b *Builder) GroupBy(columns ...string) *Builder {
-	b.query.AddClause(fmt.Sprintf("GROUP BY %s", strings.Join(columns, ", ")))
-	return b
+// OrWhere generates "where (%s) or (%s)" combining the existing where clause with the expression
+// and adds bindings for each value. If there is no where clause, it behaves like Where
+// NOTE: Bindings are appended, therefore clauses with bindings after where should be added later
+func (b *Builder) OrWhere(expression string, bindings ...interface{}) *Builder {
+	return b.combineWhere("OR", expression, bindings...)
 }
 
-// Having generates "having %s" for each expression
-func (b *Builder) Having(expressions ...string) *Builder {
-	b.query.AddClause(fmt.Sprintf("HAVING %s", strings.Join(expressions, ", ")))
-	return b
-}
+func (b *Builder) combineWhere(op string, expression string, bindings ...interface{}) *Builder {
+	if expression == "" {
+		return b
+	}
 
-// Limit generates limit %d offset %d for offset and count
-func (b *Builder) Limit(offset int, count int) *Builder {
-	b.query.AddClause(fmt.Sprintf("LIMIT %d OFFSET %d", count, offset))
-	return b
+	for k := len(b.query.clauses) - 1; k >= 0; k-- {
+		existing := b.query.clauses[k]
+		if !strings.HasPrefix(existing, "WHERE ") {
+			continue
+		}
+
+		// avoid double wrapping the expressions combined with the same operator into this where clause
+		combined := b.whereOp == op && b.whereClause == existing
+		existing = strings.TrimPrefix(existing, "WHERE ")
+		if !combined {
+			existing = fmt.Sprintf("(%s)", existing)
+		}
+		b.query.clauses[k] = fmt.Sprintf("WHERE %s %s (%s)", existing, op, expression)
+		b.query.AddBinding(bindings...)
+		b.whereOp = op
+		b.whereClause = b.query.clauses[k]
+		return b
+	}
+
+	return b.Where(expression, bindings...)
 }
 
-// aggregates
+// WhereIn generates "where %s in (%s)" for key and adds bindings for each value
+func (b *Builder) WhereIn(key string, values ...interface{}) *Builder {
+	return b.Where(b.In(key, values...))
+}
 
-// Avg function generates "avg(%s)" statement for column
-func (b *Builder) Avg(column string) string {
-	return fmt.Sprintf("AVG(%s)", b.adapter.Escape(column))
+// WhereNotIn generates "where %s not in (%s)" for key and adds bindings for each value
+func (b *Builder) WhereNotIn(key string, values ...interface{}) *Builder {
+	return b.Where(b.NotIn(key, values...))
 }
 
-// Count function generates "count(%s)" statement for column
-func (b *Builder) Count(column string) string {
-	return fmt.Sprintf("COUNT(%s)", b.adapter.Escape(column))
+// WhereEq generates "where %s = placeholder" for key and adds binding for value
+func (b *Builder) WhereEq(key string, value interface{}) *Builder {
+	return b.Where(b.Eq(key, value))
 }
 
-// Sum function generates "sum(%s)" statement for column
-func (b *Builder) Sum(column string) string {
-	return fmt.Sprintf("SUM(%s)", b.adapter.Escape(column))
+// WhereNotEq generates "where %s != placeholder" for key and adds binding for value
+func (b *Builder) WhereNotEq(key string, value interface{}) *Builder {
+	return b.Where(b.NotEq(key, value))
 }
 
-// Min function generates "min(%s)" statement for column
-func (b *Builder) Min(column string) string {
-	return fmt.Sprintf("MIN(%s)", b.adapter.Escape(column))
+// WhereGt generates "where %s > placeholder" for key and adds binding for value
+func (b *Builder) WhereGt(key string, value interface{}) *Builder {
+	return b.Where(b.Gt(key, value))
 }
 
-// Max function generates "max(%s)" statement for column
-func (b *Builder) Max(column string) string {
-	return fmt.Sprintf("MAX(%s)", b.adapter.Escape(column))
+// WhereGte generates "where %s >= placeholder" for key and adds binding for value
+func (b *Builder) WhereGte(key string, value interface{}) *Builder {
+	return b.Where(b.Gte(key, value))
 }
 
-// expressions
+// WhereLt generates "where %s < placeholder" for key and adds binding for value
+func (b *Builder) WhereLt(key string, value interface{}) *Builder {
+	return b.Where(b.St(key, value))
+}
 
-// NotIn function generates "%s not in (%s)" for key and adds bindings for each value
-func (b *Builder) NotIn(key string, values ...interface{}) string {
-	b.query.AddBinding(values...)
-	return fmt.Sprintf("%s NOT IN (%s)", b.adapter.Escape(key), strings.Join(b.adapter.Placeholders(values...), ","))
+// WhereLte generates "where %s <= placeholder" for key and adds binding for value
+func (b *Builder) WhereLte(key string, value interface{}) *Builder {
+	return b.Where(b.Ste(key, value))
 }
 
-// In function generates "%s in (%s)" for key and adds bindings for each value
-func (b *Builder) In(key string, values ...interface{}) string {
-	b.query.AddBinding(values...)
-	return fmt.Sprintf("%s IN (%s)", b.adapter.Escape(key), strings.Join(b.adapter.Placeholders(values...), ","))
+// WhereLike generates "where %s like placeholder" for key and adds binding for pattern
+func (b *Builder) WhereLike(key string, pattern interface{}) *Builder {
+	return b.Where(b.Like(key, pattern))
 }
 
-// NotEq function generates "%s != placeholder" for key and adds binding for value
-func (b *Builder) NotEq(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s != %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereNotLike generates "where %s not like placeholder" for key and adds binding for pattern
+func (b *Builder) WhereNotLike(key string, pattern interface{}) *Builder {
+	return b.Where(b.NotLike(key, pattern))
 }
 
-// Eq function generates "%s = placeholder" for key and adds binding for value
-func (b *Builder) Eq(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s = %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereILike generates "where %s ilike placeholder" for key and adds binding for pattern
+// NOTE: Only supported by postgres
+func (b *Builder) WhereILike(key string, pattern interface{}) *Builder {
+	return b.Where(b.ILike(key, pattern))
 }
 
-// Gt function generates "%s > placeholder" for key and adds binding for value
-func (b *Builder) Gt(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s > %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereNotILike generates "where %s not ilike placeholder" for key and adds binding for pattern
+// NOTE: Only supported by postgres
+func (b *Builder) WhereNotILike(key string, pattern interface{}) *Builder {
+	return b.Where(b.NotILike(key, pattern))
 }
 
-// Gte function generates "%s >= placeholder" for key and adds binding for value
-func (b *Builder) Gte(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s >= %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereIsNull generates "where %s is null" for key
+func (b *Builder) WhereIsNull(key string) *Builder {
+	return b.Where(b.IsNull(key))
 }
 
-// St function generates "%s < placeholder" for key and adds binding for value
-func (b *Builder) St(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s < %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereIsNotNull generates "where %s is not null" for key
+func (b *Builder) WhereIsNotNull(key string) *Builder {
+	return b.Where(b.IsNotNull(key))
 }
 
-// Ste function generates "%s <= placeholder" for key and adds binding for value
-func (b *Builder) Ste(key string, value interface{}) string {
-	b.query.AddBinding(value)
-	return fmt.Sprintf("%s <= %s", b.adapter.Escape(key), b.adapter.Placeholder())
+// WhereBetween generates "where %s between placeholder and placeholder" for key and adds bindings for low & high
+func (b *Builder) WhereBetween(key string, low interface{}, high interface{}) *Builder {
+	return b.Where(b.Between(key, low, high))
 }
 
-// And function generates " AND " between any number of expressions
-func (b *Builder) And(expressions ...string) string {
-	if len(expressions) == 0 {
-		return ""
-	}
-	return fmt.Sprintf("(%s)", strings.Join(expressions, " AND "))
+// WhereNotBetween generates "where %s not between placeholder and placeholder" for key and adds bindings for low & high
+func (b *Builder) WhereNotBetween(key string, low interface{}, high interface{}) *Builder {
+	return b.Where(b.NotBetween(key, low, high))
 }
 
-// Or function generates " OR " between any number of expressions
-func (b *Builder) Or(expressions ...string) string {
-	return strings.Join(expressions, " OR ")
+// OrderBy generates "order by %s" for each expression
+func (b *Builder) OrderBy(expressions ...string) *Builder {
+	b.query.AddClause(fmt.Sprintf("ORDER BY %s", strings.Join(expressions, ", ")))
+	return b
 }
 
-// CreateTable generates generic CREATE TABLE statement
-func (b *Builder) CreateTable(table string, fields []string, constraints []string) *Builder {
-	b.query.AddClause(fmt.Sprintf("CREATE TABLE %s(", b.adapter.Escape(table)))
+// Direction is the sort direction of order by clauses
+type Direction string
 
-	for k, f := range fields {
-		clause := fmt.Sprintf("\t%s", f)
-		if len(fields)-1 > k || len(constraints) > 0 {
-			clause += ","
-		}
-		b.query.AddClause(clause)
-	}
+// Sort directions
+const (
+	Asc  Direction = "ASC"
+	Desc Direction = "DESC"
+)
+
+// OrderByCol generates "order by %s %s" for column and direction
+func (b *Builder) OrderByCol(column string, dir Direction) *Builder {
+	return b.OrderBy(fmt.Sprintf("%s %s", b.adapter.Escape(column), dir))
+}
+
+// RandomOrder generates "order by random()" or "order by rand()" for mysql
+func (b *Builder) RandomOrder() *Builder {
+	if b.adapter.Driver() == "mysql" {
+		return b.OrderBy("RAND()")
+	}
+	return b.OrderBy("RANDOM()")
+}
+
+// NullsFirst generates "%s nulls first" for column to be used in order by
+// It is a no-op for drivers that don't support null ordering such as mysql
+func (b *Builder) NullsFirst(column string) string {
+	if !b.adapter.SupportsFeature(FeatureNullsOrdering) {
+		return b.adapter.Escape(column)
+	}
+	return fmt.Sprintf("%s NULLS FIRST", b.adapter.Escape(column))
+}
+
+// NullsLast generates "%s nulls last" for column to be used in order by
+// It is a no-op for drivers that don't support null ordering such as mysql
+func (b *Builder) NullsLast(column string) string {
+	if !b.adapter.SupportsFeature(FeatureNullsOrdering) {
+		return b.adapter.Escape(column)
+	}
+	return fmt.Sprintf("%s NULLS LAST", b.adapter.Escape(column))
+}
+
+// GroupBy generates "group by %s" for each column
+func (b *Builder) GroupBy(columns ...string) *Builder {
+	b.query.AddClause(fmt.Sprintf("GROUP BY %s", strings.Join(columns, ", ")))
+	return b
+}
+
+// GroupBySets generates "group by grouping sets ((%s), ...)" for each group of columns
+// NOTE: Not supported by sqlite & mysql
+func (b *Builder) GroupBySets(groups [][]string) *Builder {
+	if !b.supports(FeatureGroupingSets) {
+		return b
+	}
+	sets := []string{}
+	for _, g := range groups {
+		sets = append(sets, fmt.Sprintf("(%s)", strings.Join(g, ", ")))
+	}
+	b.query.AddClause(fmt.Sprintf("GROUP BY GROUPING SETS (%s)", strings.Join(sets, ", ")))
+	return b
+}
+
+// GroupByRollup generates "group by rollup (%s)" for each column
+// mysql generates "group by %s with rollup" instead
+// NOTE: Not supported by sqlite
+func (b *Builder) GroupByRollup(columns ...string) *Builder {
+	if !b.supports(FeatureRollup) {
+		return b
+	}
+	if b.adapter.Driver() == "mysql" {
+		b.query.AddClause(fmt.Sprintf("GROUP BY %s WITH ROLLUP", strings.Join(columns, ", ")))
+	} else {
+		b.query.AddClause(fmt.Sprintf("GROUP BY ROLLUP (%s)", strings.Join(columns, ", ")))
+	}
+	return b
+}
+
+// GroupByCube generates "group by cube (%s)" for each column
+// NOTE: Not supported by sqlite & mysql
+func (b *Builder) GroupByCube(columns ...string) *Builder {
+	if !b.supports(FeatureCube) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("GROUP BY CUBE (%s)", strings.Join(columns, ", ")))
+	return b
+}
+
+// Pivot appends "%s(case when %s = '%s' then %s end) as %s" to the select clause for each label
+// and generates "group by %s" for group columns. Labels are always quoted since they become column aliases.
+// If group columns are not given, the previously selected columns are used, which must be plain columns
+func (b *Builder) Pivot(valueCol string, labelCol string, labels []string, aggregateFn string, groupCols ...string) *Builder {
+	for k, clause := range b.query.clauses {
+		if !strings.HasPrefix(clause, "SELECT ") {
+			continue
+		}
+
+		columns := strings.TrimPrefix(clause, "SELECT ")
+		if len(groupCols) == 0 {
+			for _, col := range strings.Split(columns, ",") {
+				col = strings.TrimSpace(col)
+				if strings.ContainsAny(col, "( ") {
+					b.query.AddError(fmt.Errorf("Pivot can't group by expression %s, group columns should be given", col))
+					return b
+				}
+				groupCols = append(groupCols, col)
+			}
+		}
+
+		pivots := []string{}
+		for _, label := range labels {
+			pivots = append(pivots, fmt.Sprintf("%s(CASE WHEN %s = %s THEN %s END) AS %s",
+				aggregateFn,
+				b.adapter.Escape(labelCol),
+				b.adapter.QuoteLiteral(label),
+				b.adapter.Escape(valueCol),
+				b.adapter.QuoteIdentifier(label),
+			))
+		}
+		b.query.clauses[k] = fmt.Sprintf("SELECT %s, %s", columns, strings.Join(pivots, ", "))
+		return b.GroupBy(groupCols...)
+	}
+
+	b.query.AddError(fmt.Errorf("Pivot requires a select clause"))
+	return b
+}
+
+// Unpivot generates "select '%s' as %s, %s as %s from %s" statements joined with "union all" for each column
+// NOTE: None of the supported drivers have a native UNPIVOT, therefore union all is used for every adapter
+func (b *Builder) Unpivot(table string, valueAlias string, nameAlias string, cols []string) *Builder {
+	for k, col := range cols {
+		if k > 0 {
+			b.query.AddClause("UNION ALL")
+		}
+		b.query.AddClause(fmt.Sprintf("SELECT %s AS %s, %s AS %s",
+			b.adapter.QuoteLiteral(col),
+			b.adapter.Escape(nameAlias),
+			b.adapter.Escape(col),
+			b.adapter.Escape(valueAlias),
+		))
+		b.From(table)
+	}
+	return b
+}
+
+// Having generates "having %s" for each expression
+func (b *Builder) Having(expressions ...string) *Builder {
+	b.query.AddClause(fmt.Sprintf("HAVING %s", strings.Join(expressions, ", ")))
+	return b
+}
+
+// Limit generates limit %d offset %d for offset and count
+func (b *Builder) Limit(offset int, count int) *Builder {
+	b.query.AddClause(fmt.Sprintf("LIMIT %d OFFSET %d", count, offset))
+	return b
+}
+
+// aggregates
+
+// AggregateStar function returns "*" to be used in aggregates such as Count without escaping
+func (b *Builder) AggregateStar() string {
+	return "*"
+}
+
+// Distinct function generates "distinct %s" for column to be used in aggregates such as Count
+func (b *Builder) Distinct(column string) string {
+	return fmt.Sprintf("DISTINCT %s", b.adapter.Escape(column))
+}
+
+// Avg function generates "avg(%s)" statement for column
+func (b *Builder) Avg(column string) string {
+	return fmt.Sprintf("AVG(%s)", b.key(column))
+}
+
+// Count function generates "count(%s)" statement for column
+func (b *Builder) Count(column string) string {
+	return fmt.Sprintf("COUNT(%s)", b.key(column))
+}
+
+// Sum function generates "sum(%s)" statement for column
+func (b *Builder) Sum(column string) string {
+	return fmt.Sprintf("SUM(%s)", b.key(column))
+}
+
+// Min function generates "min(%s)" statement for column
+func (b *Builder) Min(column string) string {
+	return fmt.Sprintf("MIN(%s)", b.key(column))
+}
+
+// Max function generates "max(%s)" statement for column
+func (b *Builder) Max(column string) string {
+	return fmt.Sprintf("MAX(%s)", b.key(column))
+}
+
+// Filter function generates "%s filter (where %s)" for aggregate and adds bindings for condition
+// NOTE: Not supported by mysql
+func (b *Builder) Filter(aggregate string, condition string, bindings ...interface{}) string {
+	if !b.supports(FeatureFilter) {
+		return aggregate
+	}
+	b.query.AddBinding(bindings...)
+	return fmt.Sprintf("%s FILTER (WHERE %s)", aggregate, condition)
+}
+
+// functions
+
+// Cast function generates "cast(%s as %s)" for key and type
+func (b *Builder) Cast(key string, typ string) string {
+	return fmt.Sprintf("CAST(%s AS %s)", b.key(key), typ)
+}
+
+// Collate function generates "%s collate %s" for expression quoting the collation
+func (b *Builder) Collate(expression string, collation string) string {
+	return fmt.Sprintf("%s COLLATE %s", expression, b.adapter.QuoteIdentifier(collation))
+}
+
+// Coalesce function generates "coalesce(%s)" for sql expressions
+func (b *Builder) Coalesce(expressions ...string) string {
+	return fmt.Sprintf("COALESCE(%s)", strings.Join(expressions, ", "))
+}
+
+// CoalesceV function generates "coalesce(%s)" where string args are sql expressions
+// and other args are added as bindings
+func (b *Builder) CoalesceV(args ...interface{}) string {
+	return fmt.Sprintf("COALESCE(%s)", strings.Join(b.arguments(args...), ", "))
+}
+
+// Concat function generates "concat(%s)" for sql expressions
+// sqlite & default drivers generate "%s || %s" instead
+func (b *Builder) Concat(expressions ...string) string {
+	switch b.adapter.Driver() {
+	case "postgres", "mysql":
+		return fmt.Sprintf("CONCAT(%s)", strings.Join(expressions, ", "))
+	default:
+		return fmt.Sprintf("(%s)", strings.Join(expressions, " || "))
+	}
+}
+
+// ConcatV function generates "concat(%s)" where string args that are plain or qualified column names
+// such as "first_name" or "u.first_name" are sql expressions and other args including other strings are added as bindings
+func (b *Builder) ConcatV(args ...interface{}) string {
+	exprs := []string{}
+	for _, arg := range args {
+		if col, ok := arg.(string); ok && identifierRegexp.MatchString(col) {
+			exprs = append(exprs, b.key(col))
+			continue
+		}
+		b.query.AddBinding(arg)
+		exprs = append(exprs, b.adapter.Placeholder())
+	}
+	return b.Concat(exprs...)
+}
+
+// FunctionCall function generates "%s(%s)" for any sql function where string args are sql expressions
+// and other args are added as bindings
+func (b *Builder) FunctionCall(name string, args ...interface{}) string {
+	return fmt.Sprintf("%s(%s)", name, strings.Join(b.arguments(args...), ", "))
+}
+
+// arguments converts string args to sql expressions and other args to placeholders adding them as bindings
+func (b *Builder) arguments(args ...interface{}) []string {
+	exprs := []string{}
+	for _, arg := range args {
+		if expr, ok := arg.(string); ok {
+			exprs = append(exprs, expr)
+			continue
+		}
+		b.query.AddBinding(arg)
+		exprs = append(exprs, b.adapter.Placeholder())
+	}
+	return exprs
+}
+
+// InlineValues converts values to comma separated sql literals without adding bindings
+// Strings are quoted, booleans are converted to TRUE or FALSE, times are converted to datetime literals of the adapter
+// and nil is converted to NULL. NaN and infinite floats are quoted for postgres whereas other drivers don't support them,
+// therefore an error is added to the active query
+// WARNING: Values are embedded into sql, never use it with user input since it is prone to sql injection
+func (b *Builder) InlineValues(values ...interface{}) string {
+	literals := []string{}
+	for _, v := range values {
+		switch value := v.(type) {
+		case nil:
+			literals = append(literals, "NULL")
+		case string:
+			literals = append(literals, b.adapter.QuoteLiteral(value))
+		case []byte:
+			literals = append(literals, b.adapter.QuoteLiteral(string(value)))
+		case bool:
+			literals = append(literals, strings.ToUpper(strconv.FormatBool(value)))
+		case time.Time:
+			literals = append(literals, b.inlineTime(value))
+		case float32:
+			literals = append(literals, b.inlineFloat(float64(value), 32))
+		case float64:
+			literals = append(literals, b.inlineFloat(value, 64))
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			literals = append(literals, fmt.Sprintf("%v", value))
+		default:
+			literals = append(literals, b.adapter.QuoteLiteral(fmt.Sprintf("%v", value)))
+		}
+	}
+	return strings.Join(literals, ", ")
+}
+
+// inlineTime returns the datetime literal of t
+// mysql datetime literals don't accept time zones, therefore t is converted to utc
+func (b *Builder) inlineTime(t time.Time) string {
+	if b.adapter.Driver() == "mysql" {
+		return b.adapter.QuoteLiteral(t.UTC().Format("2006-01-02 15:04:05.999999"))
+	}
+	return b.adapter.QuoteLiteral(t.Format(time.RFC3339Nano))
+}
+
+// inlineFloat returns the literal of f
+// postgres accepts quoted NaN and infinity literals whereas other drivers don't have them
+func (b *Builder) inlineFloat(f float64, bitSize int) string {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	if b.adapter.Driver() != "postgres" {
+		b.query.AddError(fmt.Errorf("%v can't be inlined for %s driver", f, b.adapter.Driver()))
+		return "NULL"
+	}
+	switch {
+	case math.IsNaN(f):
+		return b.adapter.QuoteLiteral("NaN")
+	case f > 0:
+		return b.adapter.QuoteLiteral("Infinity")
+	}
+	return b.adapter.QuoteLiteral("-Infinity")
+}
+
+// DefaultValue returns the default literal of the adapter for column type
+// such as an empty string literal for strings, "0" for numbers, "FALSE" for booleans and "NOW()" for timestamps.
+// The length of the type is ignored and "NULL" is returned for unknown types
+func (b *Builder) DefaultValue(colType string) string {
+	typ := strings.ToUpper(strings.TrimSpace(strings.SplitN(colType, "(", 2)[0]))
+	switch typ {
+	case "STRING", "CHAR", "VARCHAR", "TEXT", "CHARACTER VARYING", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT":
+		return "''"
+	case "INT", "INTEGER", "SMALLINT", "BIGINT", "TINYINT", "MEDIUMINT", "FLOAT", "REAL", "DOUBLE", "DOUBLE PRECISION", "DECIMAL", "NUMERIC":
+		return "0"
+	case "BOOL", "BOOLEAN":
+		if b.adapter.Driver() == "sqlite3" {
+			return "0"
+		}
+		return "FALSE"
+	case "TIMESTAMP", "TIMESTAMPTZ", "DATETIME":
+		if b.adapter.Driver() == "postgres" {
+			return "NOW()"
+		}
+		return "CURRENT_TIMESTAMP"
+	}
+	return "NULL"
+}
+
+// GenUUID returns the uuid generation expression of the adapter
+// postgres 13+ generates "gen_random_uuid()", older versions generate "uuid_generate_v4()" of uuid-ossp extension
+// and mysql generates "UUID()"
+// NOTE: Not supported by sqlite
+func (b *Builder) GenUUID() string {
+	if !b.supports(FeatureUUID) {
+		return ""
+	}
+	if b.adapter.Driver() == "mysql" {
+		return "UUID()"
+	}
+	if b.adapter.SupportsFeature(FeatureGenRandomUUID) {
+		return "gen_random_uuid()"
+	}
+	return "uuid_generate_v4()"
+}
+
+// expressions
+
+// NotIn function generates "%s not in (%s)" for key and adds bindings for each value
+func (b *Builder) NotIn(key string, values ...interface{}) string {
+	b.query.AddBinding(values...)
+	return fmt.Sprintf("%s NOT IN (%s)", b.key(key), strings.Join(b.adapter.Placeholders(values...), ","))
+}
+
+// In function generates "%s in (%s)" for key and adds bindings for each value
+func (b *Builder) In(key string, values ...interface{}) string {
+	b.query.AddBinding(values...)
+	return fmt.Sprintf("%s IN (%s)", b.key(key), strings.Join(b.adapter.Placeholders(values...), ","))
+}
+
+// EqAny function generates "%s = any(placeholder)" for key and adds a single array binding for values
+// Drivers other than postgres fall back to "%s in (%s)"
+func (b *Builder) EqAny(key string, values ...interface{}) string {
+	if !b.adapter.SupportsFeature(FeatureAny) {
+		return b.In(key, values...)
+	}
+	b.query.AddBinding(pq.Array(values))
+	return fmt.Sprintf("%s = ANY(%s)", b.key(key), b.adapter.Placeholder())
+}
+
+// NotEqAll function generates "%s != all(placeholder)" for key and adds a single array binding for values
+// Drivers other than postgres fall back to "%s not in (%s)"
+func (b *Builder) NotEqAll(key string, values ...interface{}) string {
+	if !b.adapter.SupportsFeature(FeatureAny) {
+		return b.NotIn(key, values...)
+	}
+	b.query.AddBinding(pq.Array(values))
+	return fmt.Sprintf("%s != ALL(%s)", b.key(key), b.adapter.Placeholder())
+}
+
+// NotEq function generates "%s != placeholder" for key and adds binding for value
+func (b *Builder) NotEq(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s != %s", b.key(key), b.adapter.Placeholder())
+}
+
+// Eq function generates "%s = placeholder" for key and adds binding for value
+func (b *Builder) Eq(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s = %s", b.key(key), b.adapter.Placeholder())
+}
+
+// Gt function generates "%s > placeholder" for key and adds binding for value
+func (b *Builder) Gt(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s > %s", b.key(key), b.adapter.Placeholder())
+}
+
+// Gte function generates "%s >= placeholder" for key and adds binding for value
+func (b *Builder) Gte(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s >= %s", b.key(key), b.adapter.Placeholder())
+}
+
+// St function generates "%s < placeholder" for key and adds binding for value
+func (b *Builder) St(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s < %s", b.key(key), b.adapter.Placeholder())
+}
+
+// Ste function generates "%s <= placeholder" for key and adds binding for value
+func (b *Builder) Ste(key string, value interface{}) string {
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s <= %s", b.key(key), b.adapter.Placeholder())
+}
+
+// Like function generates "%s like placeholder" for key and adds binding for pattern
+func (b *Builder) Like(key string, pattern interface{}) string {
+	b.query.AddBinding(pattern)
+	return fmt.Sprintf("%s LIKE %s", b.key(key), b.adapter.Placeholder())
+}
+
+// NotLike function generates "%s not like placeholder" for key and adds binding for pattern
+func (b *Builder) NotLike(key string, pattern interface{}) string {
+	b.query.AddBinding(pattern)
+	return fmt.Sprintf("%s NOT LIKE %s", b.key(key), b.adapter.Placeholder())
+}
+
+// ILike function generates "%s ilike placeholder" for key and adds binding for pattern
+// NOTE: Only supported by postgres
+func (b *Builder) ILike(key string, pattern interface{}) string {
+	if !b.supports(FeatureILike) {
+		return ""
+	}
+	b.query.AddBinding(pattern)
+	return fmt.Sprintf("%s ILIKE %s", b.key(key), b.adapter.Placeholder())
+}
+
+// NotILike function generates "%s not ilike placeholder" for key and adds binding for pattern
+// NOTE: Only supported by postgres
+func (b *Builder) NotILike(key string, pattern interface{}) string {
+	if !b.supports(FeatureILike) {
+		return ""
+	}
+	b.query.AddBinding(pattern)
+	return fmt.Sprintf("%s NOT ILIKE %s", b.key(key), b.adapter.Placeholder())
+}
+
+// IsNull function generates "%s is null" for key
+func (b *Builder) IsNull(key string) string {
+	return fmt.Sprintf("%s IS NULL", b.key(key))
+}
+
+// IsNotNull function generates "%s is not null" for key
+func (b *Builder) IsNotNull(key string) string {
+	return fmt.Sprintf("%s IS NOT NULL", b.key(key))
+}
+
+// Between function generates "%s between placeholder and placeholder" for key and adds bindings for low & high
+func (b *Builder) Between(key string, low interface{}, high interface{}) string {
+	b.query.AddBinding(low, high)
+	return fmt.Sprintf("%s BETWEEN %s AND %s", b.key(key), b.adapter.Placeholder(), b.adapter.Placeholder())
+}
+
+// NotBetween function generates "%s not between placeholder and placeholder" for key and adds bindings for low & high
+func (b *Builder) NotBetween(key string, low interface{}, high interface{}) string {
+	b.query.AddBinding(low, high)
+	return fmt.Sprintf("%s NOT BETWEEN %s AND %s", b.key(key), b.adapter.Placeholder(), b.adapter.Placeholder())
+}
+
+// And function generates " AND " between any number of expressions
+func (b *Builder) And(expressions ...string) string {
+	if len(expressions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%s)", strings.Join(expressions, " AND "))
+}
+
+// Or function generates " OR " between any number of expressions
+func (b *Builder) Or(expressions ...string) string {
+	return strings.Join(expressions, " OR ")
+}
+
+// CreateTable generates generic CREATE TABLE statement
+func (b *Builder) CreateTable(table string, fields []string, constraints []string) *Builder {
+	b.query.AddClause(fmt.Sprintf("CREATE TABLE %s(", b.table(table)))
+
+	for k, f := range fields {
+		clause := fmt.Sprintf("\t%s", f)
+		if len(fields)-1 > k || len(constraints) > 0 {
+			clause += ","
+		}
+		b.query.AddClause(clause)
+	}
 
 	for k, c := range constraints {
 		constraint := fmt.Sprintf("\t%s", c)
@@ -386,13 +1312,18 @@ func (b *Builder) CreateTable(table string, fields []string, constraints []strin
 
 // AlterTable generates generic ALTER TABLE statement
 func (b *Builder) AlterTable(table string) *Builder {
+	return b.alterTable(b.table(table))
+}
+
+// alterTable generates ALTER TABLE statement for the table name which is already prefixed & escaped
+func (b *Builder) alterTable(table string) *Builder {
 	b.query.AddClause(fmt.Sprintf("ALTER TABLE %s", table))
 	return b
 }
 
 // DropTable generates generic DROP TABLE statement
 func (b *Builder) DropTable(table string) *Builder {
-	b.query.AddClause(fmt.Sprintf("DROP TABLE %s", b.adapter.Escape(table)))
+	b.query.AddClause(fmt.Sprintf("DROP TABLE %s", b.table(table)))
 	return b
 }
 
@@ -410,6 +1341,1355 @@ func (b *Builder) Drop(colName string) *Builder {
 
 // CreateIndex generates an index on columns
 func (b *Builder) CreateIndex(indexName string, tableName string, columns ...string) *Builder {
-	b.query.AddClause(fmt.Sprintf("CREATE INDEX %s ON %s(%s)", indexName, tableName, strings.Join(b.adapter.EscapeAll(columns), ",")))
+	b.query.AddClause(fmt.Sprintf("CREATE INDEX %s ON %s(%s)", indexName, b.table(tableName), strings.Join(b.adapter.EscapeAll(columns), ",")))
+	return b
+}
+
+// CreateFullTextIndex generates a full text index on columns
+// mysql generates "create fulltext index" whereas postgres generates a gin index using to_tsvector
+// The to_tsvector expression should be used as is by the queries of postgres to use the index
+// NOTE: Not supported by sqlite
+func (b *Builder) CreateFullTextIndex(indexName string, tableName string, columns ...string) *Builder {
+	if !b.supports(FeatureFullTextIndex) {
+		return b
+	}
+	columns = b.adapter.EscapeAll(columns)
+	if b.adapter.Driver() == "postgres" {
+		// null columns are coalesced since concatenating null makes the whole document null.
+		// concat_ws can't be used as it is not immutable
+		documents := []string{}
+		for _, c := range columns {
+			documents = append(documents, fmt.Sprintf("coalesce(%s, '')", c))
+		}
+		b.query.AddClause(fmt.Sprintf("CREATE INDEX %s ON %s USING GIN(to_tsvector('english', %s))", indexName, b.table(tableName), strings.Join(documents, " || ' ' || ")))
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE FULLTEXT INDEX %s ON %s(%s)", indexName, b.table(tableName), strings.Join(columns, ",")))
+	return b
+}
+
+// CreateIndexInclude generates a covering index on columns including the non key columns
+// NOTE: Only supported by postgres 11+
+func (b *Builder) CreateIndexInclude(indexName string, tableName string, columns []string, includeColumns []string) *Builder {
+	if !b.supports(FeatureIndexInclude) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE INDEX %s ON %s(%s) INCLUDE (%s)", indexName, b.table(tableName), strings.Join(b.adapter.EscapeAll(columns), ","), strings.Join(b.adapter.EscapeAll(includeColumns), ",")))
+	return b
+}
+
+// CreateIndexConcurrently generates an index on columns without locking writes on the table
+// NOTE: Only supported by postgres. The query is not transactional, therefore sessions reject it
+// and it should be executed using the engine
+func (b *Builder) CreateIndexConcurrently(indexName string, tableName string, columns ...string) *Builder {
+	if !b.supports(FeatureIndexConcurrently) {
+		return b
+	}
+	b.query.nonTransactional = true
+	b.query.AddClause(fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON %s(%s)", indexName, b.table(tableName), strings.Join(b.adapter.EscapeAll(columns), ",")))
 	return b
 }
+
+// AnalyzeTable generates "analyze %s" statement for table
+// NOTE: Only supported by postgres
+func (b *Builder) AnalyzeTable(table string) *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("ANALYZE %s", b.table(table)))
+	return b
+}
+
+// VacuumOptions is the set of options of vacuum statement
+type VacuumOptions struct {
+	Full    bool
+	Freeze  bool
+	Verbose bool
+	Analyze bool
+}
+
+// VacuumTable generates "vacuum (%s) %s" statement for table and options
+// NOTE: Only supported by postgres. The query is not transactional, therefore sessions reject it
+// and it should be executed using the engine
+func (b *Builder) VacuumTable(table string, opts VacuumOptions) *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+	b.query.nonTransactional = true
+
+	options := []string{}
+	if opts.Full {
+		options = append(options, "FULL")
+	}
+	if opts.Freeze {
+		options = append(options, "FREEZE")
+	}
+	if opts.Verbose {
+		options = append(options, "VERBOSE")
+	}
+	if opts.Analyze {
+		options = append(options, "ANALYZE")
+	}
+
+	if len(options) == 0 {
+		b.query.AddClause(fmt.Sprintf("VACUUM %s", b.table(table)))
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("VACUUM (%s) %s", strings.Join(options, ", "), b.table(table)))
+	return b
+}
+
+// ReindexOption is the option of reindex statement that is either the type of target or concurrently
+type ReindexOption string
+
+// Reindex options
+const (
+	ReindexIndex        ReindexOption = "INDEX"
+	ReindexTable        ReindexOption = "TABLE"
+	ReindexSchema       ReindexOption = "SCHEMA"
+	ReindexDatabase     ReindexOption = "DATABASE"
+	ReindexConcurrently ReindexOption = "CONCURRENTLY"
+)
+
+// Reindex generates "reindex %s %s" statement for target
+// The type of target is either given as an option or as a prefix of target such as "INDEX index_user_id".
+// It defaults to table
+// NOTE: Only supported by postgres
+func (b *Builder) Reindex(target string, opts ...ReindexOption) *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+
+	typ := ReindexTable
+	for _, t := range []ReindexOption{ReindexIndex, ReindexTable, ReindexSchema, ReindexDatabase} {
+		prefix := fmt.Sprintf("%s ", t)
+		if strings.HasPrefix(strings.ToUpper(target), prefix) {
+			typ = t
+			target = strings.TrimSpace(target[len(prefix):])
+		}
+	}
+
+	concurrently := false
+	for _, opt := range opts {
+		if opt == ReindexConcurrently {
+			concurrently = true
+		} else {
+			typ = opt
+		}
+	}
+
+	// schema qualified indices such as "public.index_user_id" are escaped piece by piece like tables
+	if typ == ReindexTable {
+		target = b.table(target)
+	} else {
+		target = b.qualifiedName(target)
+	}
+
+	// reindexing concurrently or reindexing multiple tables can't run inside a transaction block
+	if concurrently || typ == ReindexSchema || typ == ReindexDatabase {
+		b.query.nonTransactional = true
+	}
+
+	if concurrently {
+		if !b.supports(FeatureReindexConcurrently) {
+			return b
+		}
+		b.query.AddClause(fmt.Sprintf("REINDEX %s CONCURRENTLY %s", typ, target))
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("REINDEX %s %s", typ, target))
+	return b
+}
+
+// SetSearchPath generates "set search_path to %s" statement for schemas
+// NOTE: Only supported by postgres
+func (b *Builder) SetSearchPath(schemas ...string) *Builder {
+	if !b.supports(FeatureSessionConfig) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("SET search_path TO %s", strings.Join(b.adapter.EscapeAll(schemas), ", ")))
+	return b
+}
+
+// SetConfig generates "set %s to '%s'" statement for run time parameter & value
+// NOTE: Only supported by postgres
+func (b *Builder) SetConfig(param string, value string) *Builder {
+	if !b.supports(FeatureSessionConfig) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("SET %s TO %s", param, b.adapter.QuoteLiteral(value)))
+	return b
+}
+
+// CreateSchema generates "create schema %s" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) CreateSchema(name string) *Builder {
+	if !b.supports(FeatureSchemas) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE SCHEMA %s", b.adapter.Escape(name)))
+	return b
+}
+
+// CreateSchemaIfNotExists generates "create schema if not exists %s" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) CreateSchemaIfNotExists(name string) *Builder {
+	if !b.supports(FeatureSchemas) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", b.adapter.Escape(name)))
+	return b
+}
+
+// DropSchema generates "drop schema %s" statement, appending "cascade" if requested
+// mysql always drops the tables of the schema, therefore cascade is omitted
+// NOTE: Not supported by sqlite
+func (b *Builder) DropSchema(name string, cascade bool) *Builder {
+	if !b.supports(FeatureSchemas) {
+		return b
+	}
+	clause := fmt.Sprintf("DROP SCHEMA %s", b.adapter.Escape(name))
+	if cascade && b.adapter.Driver() != "mysql" {
+		clause += " CASCADE"
+	}
+	b.query.AddClause(clause)
+	return b
+}
+
+// Grant generates "grant %s on %s %s to %s" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) Grant(privilege string, objectType string, objectName string, grantee string) *Builder {
+	if !b.supports(FeaturePrivileges) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("GRANT %s ON %s %s TO %s", privilege, objectType, b.qualifiedName(objectName), grantee))
+	return b
+}
+
+// Revoke generates "revoke %s on %s %s from %s" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) Revoke(privilege string, objectType string, objectName string, grantee string) *Builder {
+	if !b.supports(FeaturePrivileges) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("REVOKE %s ON %s %s FROM %s", privilege, objectType, b.qualifiedName(objectName), grantee))
+	return b
+}
+
+// RoleOptions is the set of options of create role statement
+type RoleOptions struct {
+	Login      bool
+	Superuser  bool
+	CreateDB   bool
+	CreateRole bool
+	// Inherit is omitted if nil, postgres defaults to inherit
+	Inherit  *bool
+	Password string
+	// ConnectionLimit is omitted if nil, postgres defaults to no limit
+	ConnectionLimit *int
+}
+
+// CreateRole generates "create role %s with %s" statement for role name and options
+// NOTE: Only supported by postgres
+func (b *Builder) CreateRole(name string, opts RoleOptions) *Builder {
+	if !b.supports(FeatureRoles) {
+		return b
+	}
+
+	options := []string{}
+	if opts.Login {
+		options = append(options, "LOGIN")
+	}
+	if opts.Superuser {
+		options = append(options, "SUPERUSER")
+	}
+	if opts.CreateDB {
+		options = append(options, "CREATEDB")
+	}
+	if opts.CreateRole {
+		options = append(options, "CREATEROLE")
+	}
+	if opts.Inherit != nil {
+		if *opts.Inherit {
+			options = append(options, "INHERIT")
+		} else {
+			options = append(options, "NOINHERIT")
+		}
+	}
+	if opts.ConnectionLimit != nil {
+		options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", *opts.ConnectionLimit))
+	}
+	if opts.Password != "" {
+		options = append(options, fmt.Sprintf("PASSWORD %s", b.adapter.QuoteLiteral(opts.Password)))
+	}
+
+	clause := fmt.Sprintf("CREATE ROLE %s", b.adapter.Escape(name))
+	if len(options) > 0 {
+		clause = fmt.Sprintf("%s WITH %s", clause, strings.Join(options, " "))
+	}
+	b.query.AddClause(clause)
+	return b
+}
+
+// DropRole generates "drop role %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) DropRole(name string) *Builder {
+	if !b.supports(FeatureRoles) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("DROP ROLE %s", b.adapter.Escape(name)))
+	return b
+}
+
+// CommentOnTable generates "comment on table %s is '%s'" statement
+// NOTE: Only supported by postgres
+func (b *Builder) CommentOnTable(table string, comment string) *Builder {
+	if !b.supports(FeatureComments) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("COMMENT ON TABLE %s IS %s", b.table(table), b.adapter.QuoteLiteral(comment)))
+	return b
+}
+
+// CommentOnColumn generates "comment on column %s.%s is '%s'" statement
+// NOTE: Only supported by postgres
+func (b *Builder) CommentOnColumn(table string, column string, comment string) *Builder {
+	if !b.supports(FeatureComments) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", b.table(table), b.adapter.Escape(column), b.adapter.QuoteLiteral(comment)))
+	return b
+}
+
+// AlterColumnDefault generates "alter table %s alter column %s set default %s" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) AlterColumnDefault(table string, column string, defaultExpr string) *Builder {
+	if !b.supports(FeatureAlterColumn) {
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause(fmt.Sprintf("ALTER COLUMN %s SET DEFAULT %s", b.adapter.Escape(column), defaultExpr))
+	return b
+}
+
+// DropColumnDefault generates "alter table %s alter column %s drop default" statement
+// NOTE: Not supported by sqlite
+func (b *Builder) DropColumnDefault(table string, column string) *Builder {
+	if !b.supports(FeatureAlterColumn) {
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause(fmt.Sprintf("ALTER COLUMN %s DROP DEFAULT", b.adapter.Escape(column)))
+	return b
+}
+
+// AlterColumnNotNull generates "alter table %s alter column %s set not null" or "drop not null" statement
+// mysql generates "alter table %s modify column %s %s not null" which requires the column type
+// NOTE: Not supported by sqlite
+func (b *Builder) AlterColumnNotNull(table string, column string, notNull bool, colType ...string) *Builder {
+	if !b.supports(FeatureAlterColumn) {
+		return b
+	}
+
+	if b.adapter.Driver() == "mysql" {
+		if len(colType) == 0 {
+			b.query.AddError(fmt.Errorf("Column type of %s is required by mysql driver", column))
+			return b
+		}
+		null := "NULL"
+		if notNull {
+			null = "NOT NULL"
+		}
+		b.alterTable(b.table(table))
+		b.query.AddClause(fmt.Sprintf("MODIFY COLUMN %s %s %s", b.adapter.Escape(column), colType[0], null))
+		return b
+	}
+
+	action := "DROP NOT NULL"
+	if notNull {
+		action = "SET NOT NULL"
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause(fmt.Sprintf("ALTER COLUMN %s %s", b.adapter.Escape(column), action))
+	return b
+}
+
+// AlterTableRenameIndex generates "alter table %s rename index %s to %s" statement for mysql
+// and "alter index %s rename to %s" statement for postgres
+// NOTE: Not supported by sqlite
+func (b *Builder) AlterTableRenameIndex(table string, oldName string, newName string) *Builder {
+	if !b.supports(FeatureRenameIndex) {
+		return b
+	}
+	if b.adapter.Driver() == "postgres" {
+		b.query.AddClause(fmt.Sprintf("ALTER INDEX %s RENAME TO %s", b.adapter.Escape(oldName), b.adapter.Escape(newName)))
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause(fmt.Sprintf("RENAME INDEX %s TO %s", b.adapter.Escape(oldName), b.adapter.Escape(newName)))
+	return b
+}
+
+// CreateMaterializedView generates "create materialized view %s as %s" statement using the query of the inner builder
+// NOTE: Only supported by postgres
+func (b *Builder) CreateMaterializedView(name string, inner *Builder) *Builder {
+	query := inner.Query()
+	if !b.supports(FeatureMaterializedView) {
+		return b
+	}
+	if len(query.Bindings()) > 0 {
+		b.query.AddError(fmt.Errorf("Materialized view %s cannot have bindings", name))
+		return b
+	}
+	for _, err := range query.Errors() {
+		b.query.AddError(err)
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS", b.adapter.Escape(name)))
+	for _, clause := range query.Clauses() {
+		b.query.AddClause(clause)
+	}
+	return b
+}
+
+// RefreshMaterializedView generates "refresh materialized view %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) RefreshMaterializedView(name string, concurrently bool) *Builder {
+	if !b.supports(FeatureMaterializedView) {
+		return b
+	}
+	if concurrently {
+		b.query.AddClause(fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", b.adapter.Escape(name)))
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("REFRESH MATERIALIZED VIEW %s", b.adapter.Escape(name)))
+	return b
+}
+
+// FuncParam is a parameter of a stored function
+type FuncParam struct {
+	Name string
+	Type string
+}
+
+// FunctionDef is the definition of a stored function
+type FunctionDef struct {
+	Parameters []FuncParam
+	Returns    string
+	// Language defaults to plpgsql
+	Language string
+	Body     string
+	// Volatility is one of VOLATILE, STABLE or IMMUTABLE
+	Volatility string
+}
+
+// CreateFunction generates "create or replace function %s(%s)" statement for function definition
+// NOTE: Only supported by postgres
+func (b *Builder) CreateFunction(name string, def FunctionDef) *Builder {
+	if !b.supports(FeatureFunctions) {
+		return b
+	}
+
+	body, ok := b.dollarQuote("function", def.Body)
+	if !ok {
+		return b
+	}
+
+	params := []string{}
+	for _, p := range def.Parameters {
+		params = append(params, strings.TrimSpace(fmt.Sprintf("%s %s", p.Name, p.Type)))
+	}
+
+	language := def.Language
+	if language == "" {
+		language = "plpgsql"
+	}
+
+	b.query.AddClause(fmt.Sprintf("CREATE OR REPLACE FUNCTION %s(%s)", name, strings.Join(params, ", ")))
+	b.query.AddClause(fmt.Sprintf("RETURNS %s", def.Returns))
+	b.query.AddClause(fmt.Sprintf("LANGUAGE %s", language))
+	if def.Volatility != "" {
+		b.query.AddClause(def.Volatility)
+	}
+	b.query.AddClause(fmt.Sprintf("AS %s", body))
+	return b
+}
+
+// dollarQuoteTag returns "$$" or "$body$" if body contains "$$"
+func dollarQuoteTag(body string) string {
+	if strings.Contains(body, "$$") {
+		return "$body$"
+	}
+	return "$$"
+}
+
+// dollarQuote wraps body with the tag of dollarQuoteTag
+// and adds an error if body contains the tag as well, which would end the body early
+func (b *Builder) dollarQuote(kind string, body string) (string, bool) {
+	tag := dollarQuoteTag(body)
+	if strings.Contains(body, tag) {
+		b.query.AddError(fmt.Errorf("%s body contains the delimiter %s", kind, tag))
+		return "", false
+	}
+	return fmt.Sprintf("%s%s%s", tag, body, tag), true
+}
+
+// TriggerOptions is the set of options of create trigger statement
+type TriggerOptions struct {
+	ForEachStatement bool
+	WhenCondition    string
+}
+
+// CreateTrigger generates "create trigger %s %s %s on %s for each row execute function %s()" statement
+// postgres versions prior to 11 generate "execute procedure" instead
+// NOTE: Only supported by postgres
+func (b *Builder) CreateTrigger(name string, timing string, event string, table string, fnName string, opts TriggerOptions) *Builder {
+	if !b.supports(FeatureTriggers) {
+		return b
+	}
+
+	b.query.AddClause(fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s", b.adapter.Escape(name), timing, event, b.table(table)))
+
+	forEach := "FOR EACH ROW"
+	if opts.ForEachStatement {
+		forEach = "FOR EACH STATEMENT"
+	}
+	b.query.AddClause(forEach)
+
+	if opts.WhenCondition != "" {
+		b.query.AddClause(fmt.Sprintf("WHEN (%s)", opts.WhenCondition))
+	}
+
+	execute := "EXECUTE FUNCTION"
+	if !b.adapter.SupportsFeature(FeatureExecuteFunction) {
+		execute = "EXECUTE PROCEDURE"
+	}
+	b.query.AddClause(fmt.Sprintf("%s %s()", execute, fnName))
+	return b
+}
+
+// DropFunction generates "drop function %s(%s)" statement for function name and argument types
+// NOTE: Only supported by postgres
+func (b *Builder) DropFunction(name string, argTypes ...string) *Builder {
+	return b.dropFunction("DROP FUNCTION", name, argTypes...)
+}
+
+// DropFunctionIfExists generates "drop function if exists %s(%s)" statement for function name and argument types
+// NOTE: Only supported by postgres
+func (b *Builder) DropFunctionIfExists(name string, argTypes ...string) *Builder {
+	return b.dropFunction("DROP FUNCTION IF EXISTS", name, argTypes...)
+}
+
+func (b *Builder) dropFunction(statement string, name string, argTypes ...string) *Builder {
+	if !b.supports(FeatureFunctions) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("%s %s(%s)", statement, name, strings.Join(argTypes, ", ")))
+	return b
+}
+
+// DropTrigger generates "drop trigger %s on %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) DropTrigger(name string, table string) *Builder {
+	return b.dropTrigger("DROP TRIGGER", name, table)
+}
+
+// DropTriggerIfExists generates "drop trigger if exists %s on %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) DropTriggerIfExists(name string, table string) *Builder {
+	return b.dropTrigger("DROP TRIGGER IF EXISTS", name, table)
+}
+
+func (b *Builder) dropTrigger(statement string, name string, table string) *Builder {
+	if !b.supports(FeatureTriggers) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("%s %s ON %s", statement, b.adapter.Escape(name), b.table(table)))
+	return b
+}
+
+// Listen generates "listen %s" statement for channel.
+// The channel is always quoted so that it matches the channel of Notify case sensitively
+// NOTE: Only supported by postgres
+func (b *Builder) Listen(channel string) *Builder {
+	if !b.supports(FeatureListenNotify) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("LISTEN %s", b.adapter.QuoteIdentifier(channel)))
+	return b
+}
+
+// Notify generates "notify %s" statement for channel.
+// Since notify doesn't accept placeholders, "select pg_notify('%s', placeholder)" is generated
+// and payload is added as a binding if it is given. Giving more than one payload is an error.
+// Like Listen, the channel is always quoted since pg_notify doesn't fold the case of the channel
+// NOTE: Only supported by postgres
+func (b *Builder) Notify(channel string, payload ...interface{}) *Builder {
+	if !b.supports(FeatureListenNotify) {
+		return b
+	}
+	if len(payload) > 1 {
+		b.query.AddError(fmt.Errorf("Notify accepts a single payload, %d are given", len(payload)))
+		return b
+	}
+	if len(payload) == 0 {
+		b.query.AddClause(fmt.Sprintf("NOTIFY %s", b.adapter.QuoteIdentifier(channel)))
+		return b
+	}
+	b.query.AddBinding(payload[0])
+	b.query.AddClause(fmt.Sprintf("SELECT pg_notify(%s, %s)", b.adapter.QuoteLiteral(channel), b.adapter.Placeholder()))
+	return b
+}
+
+// LockMode is the lock mode of lock table statement
+type LockMode string
+
+// Lock modes
+const (
+	AccessShare          LockMode = "ACCESS SHARE"
+	RowShare             LockMode = "ROW SHARE"
+	RowExclusive         LockMode = "ROW EXCLUSIVE"
+	ShareUpdateExclusive LockMode = "SHARE UPDATE EXCLUSIVE"
+	Share                LockMode = "SHARE"
+	ShareRowExclusive    LockMode = "SHARE ROW EXCLUSIVE"
+	Exclusive            LockMode = "EXCLUSIVE"
+	AccessExclusive      LockMode = "ACCESS EXCLUSIVE"
+)
+
+// LockTable generates "lock table %s in %s mode" statement for tables
+// NOTE: Only supported by postgres
+func (b *Builder) LockTable(tables []string, mode LockMode) *Builder {
+	if !b.supports(FeatureLockTable) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("LOCK TABLE %s IN %s MODE", strings.Join(b.tables(tables), ", "), mode))
+	return b
+}
+
+// Savepoint generates "savepoint %s" statement
+func (b *Builder) Savepoint(name string) *Builder {
+	b.query.AddClause(fmt.Sprintf("SAVEPOINT %s", b.adapter.Escape(name)))
+	return b
+}
+
+// RollbackToSavepoint generates "rollback to savepoint %s" statement
+func (b *Builder) RollbackToSavepoint(name string) *Builder {
+	b.query.AddClause(fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", b.adapter.Escape(name)))
+	return b
+}
+
+// ReleaseSavepoint generates "release savepoint %s" statement
+func (b *Builder) ReleaseSavepoint(name string) *Builder {
+	b.query.AddClause(fmt.Sprintf("RELEASE SAVEPOINT %s", b.adapter.Escape(name)))
+	return b
+}
+
+// SetConstraintsDeferred generates "set constraints %s deferred" statement
+// If no constraints are given, all deferrable constraints are deferred
+// NOTE: Only supported by postgres
+func (b *Builder) SetConstraintsDeferred(constraints ...string) *Builder {
+	return b.setConstraints("DEFERRED", constraints)
+}
+
+// SetConstraintsImmediate generates "set constraints %s immediate" statement
+// If no constraints are given, all deferrable constraints are checked immediately
+// NOTE: Only supported by postgres
+func (b *Builder) SetConstraintsImmediate(constraints ...string) *Builder {
+	return b.setConstraints("IMMEDIATE", constraints)
+}
+
+func (b *Builder) setConstraints(mode string, constraints []string) *Builder {
+	if !b.supports(FeatureSetConstraints) {
+		return b
+	}
+	names := "ALL"
+	if len(constraints) > 0 {
+		names = strings.Join(b.adapter.EscapeAll(constraints), ", ")
+	}
+	b.query.AddClause(fmt.Sprintf("SET CONSTRAINTS %s %s", names, mode))
+	return b
+}
+
+// Do generates "do language %s %s" statement for anonymous code blocks
+// Language defaults to plpgsql and the body is dollar quoted
+// NOTE: Only supported by postgres
+func (b *Builder) Do(body string, language ...string) *Builder {
+	if !b.supports(FeatureDo) {
+		return b
+	}
+
+	body, ok := b.dollarQuote("do block", body)
+	if !ok {
+		return b
+	}
+
+	lang := "plpgsql"
+	if len(language) > 0 && language[0] != "" {
+		lang = language[0]
+	}
+
+	b.query.AddClause(fmt.Sprintf("DO LANGUAGE %s %s", lang, body))
+	return b
+}
+
+// PrepareDDL generates "prepare %s (%s) as %s" statement using the query of the stmt builder
+// Placeholders of stmt refer to the parameters of the prepared statement, therefore its bindings are discarded
+// NOTE: Only supported by postgres
+func (b *Builder) PrepareDDL(name string, paramTypes []string, stmt *Builder) *Builder {
+	if !b.supports(FeaturePrepare) {
+		return b
+	}
+	query := stmt.Query()
+	for _, err := range query.Errors() {
+		b.query.AddError(err)
+	}
+	sql := strings.TrimSuffix(query.SQL(), ";")
+	if len(paramTypes) > 0 {
+		b.query.AddClause(fmt.Sprintf("PREPARE %s (%s) AS %s", b.adapter.Escape(name), strings.Join(paramTypes, ", "), sql))
+	} else {
+		b.query.AddClause(fmt.Sprintf("PREPARE %s AS %s", b.adapter.Escape(name), sql))
+	}
+	return b
+}
+
+// ExecutePrepared generates "execute %s(%s)" statement for prepared statement and adds bindings for each arg
+// NOTE: Only supported by postgres
+func (b *Builder) ExecutePrepared(name string, args ...interface{}) *Builder {
+	if !b.supports(FeaturePrepare) {
+		return b
+	}
+	if len(args) == 0 {
+		b.query.AddClause(fmt.Sprintf("EXECUTE %s", b.adapter.Escape(name)))
+		return b
+	}
+	b.query.AddBinding(args...)
+	b.query.AddClause(fmt.Sprintf("EXECUTE %s(%s)", b.adapter.Escape(name), strings.Join(b.adapter.Placeholders(args...), ",")))
+	return b
+}
+
+// LoadDataOptions is the set of options of load data infile statement
+type LoadDataOptions struct {
+	FieldsTerminatedBy string
+	EnclosedBy         string
+	LinesTerminatedBy  string
+	IgnoreLines        int
+	ColumnMapping      []string
+}
+
+// LoadDataInfile generates "load data infile %s into table %s" statement for bulk loading files into table
+// NOTE: Only supported by mysql
+func (b *Builder) LoadDataInfile(filePath string, table string, opts LoadDataOptions) *Builder {
+	if !b.supports(FeatureLoadData) {
+		return b
+	}
+
+	b.query.AddClause(fmt.Sprintf("LOAD DATA INFILE %s", b.adapter.QuoteLiteral(filePath)))
+	b.query.AddClause(fmt.Sprintf("INTO TABLE %s", b.table(table)))
+
+	fields := []string{}
+	if opts.FieldsTerminatedBy != "" {
+		fields = append(fields, fmt.Sprintf("TERMINATED BY %s", b.adapter.QuoteLiteral(opts.FieldsTerminatedBy)))
+	}
+	if opts.EnclosedBy != "" {
+		fields = append(fields, fmt.Sprintf("ENCLOSED BY %s", b.adapter.QuoteLiteral(opts.EnclosedBy)))
+	}
+	if len(fields) > 0 {
+		b.query.AddClause(fmt.Sprintf("FIELDS %s", strings.Join(fields, " ")))
+	}
+	if opts.LinesTerminatedBy != "" {
+		b.query.AddClause(fmt.Sprintf("LINES TERMINATED BY %s", b.adapter.QuoteLiteral(opts.LinesTerminatedBy)))
+	}
+	if opts.IgnoreLines > 0 {
+		b.query.AddClause(fmt.Sprintf("IGNORE %d LINES", opts.IgnoreLines))
+	}
+	if len(opts.ColumnMapping) > 0 {
+		b.query.AddClause(fmt.Sprintf("(%s)", strings.Join(b.adapter.EscapeAll(opts.ColumnMapping), ", ")))
+	}
+	return b
+}
+
+// Cluster generates "cluster %s using %s" statement reordering table by index
+// NOTE: Only supported by postgres
+func (b *Builder) Cluster(table string, index string) *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CLUSTER %s USING %s", b.table(table), b.adapter.Escape(index)))
+	return b
+}
+
+// ClusterAll generates "cluster" statement re-clustering all previously clustered tables
+// NOTE: Only supported by postgres
+func (b *Builder) ClusterAll() *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+	b.query.AddClause("CLUSTER")
+	return b
+}
+
+// Checkpoint generates "checkpoint" statement forcing a write ahead log checkpoint
+// NOTE: Only supported by postgres
+func (b *Builder) Checkpoint() *Builder {
+	if !b.supports(FeatureMaintenance) {
+		return b
+	}
+	b.query.AddClause("CHECKPOINT")
+	return b
+}
+
+// SetRole generates "set role %s" statement changing the current role of the session
+// NOTE: Only supported by postgres
+func (b *Builder) SetRole(role string) *Builder {
+	if !b.supports(FeatureRoles) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("SET ROLE %s", b.adapter.Escape(role)))
+	return b
+}
+
+// ResetRole generates "reset role" statement
+// NOTE: Only supported by postgres
+func (b *Builder) ResetRole() *Builder {
+	if !b.supports(FeatureRoles) {
+		return b
+	}
+	b.query.AddClause("RESET ROLE")
+	return b
+}
+
+// EnableRLS generates "alter table %s enable row level security" statement
+// NOTE: Only supported by postgres
+func (b *Builder) EnableRLS(table string) *Builder {
+	return b.rowLevelSecurity(table, "ENABLE")
+}
+
+// DisableRLS generates "alter table %s disable row level security" statement
+// NOTE: Only supported by postgres
+func (b *Builder) DisableRLS(table string) *Builder {
+	return b.rowLevelSecurity(table, "DISABLE")
+}
+
+// ForceRLS generates "alter table %s force row level security" statement
+// which applies row level security policies to the table owner as well
+// NOTE: Only supported by postgres
+func (b *Builder) ForceRLS(table string) *Builder {
+	return b.rowLevelSecurity(table, "FORCE")
+}
+
+func (b *Builder) rowLevelSecurity(table string, action string) *Builder {
+	if !b.supports(FeatureRowLevelSecurity) {
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause(fmt.Sprintf("%s ROW LEVEL SECURITY", action))
+	return b
+}
+
+// PolicyOptions is the set of options of create policy statement
+type PolicyOptions struct {
+	// As is one of PERMISSIVE or RESTRICTIVE, postgres defaults to PERMISSIVE
+	As string
+	// Command is one of ALL, SELECT, INSERT, UPDATE or DELETE
+	Command   string
+	Roles     []string
+	UsingExpr string
+	CheckExpr string
+}
+
+// policyRoles are the role keywords of policies that are not escaped
+var policyRoles = map[string]bool{
+	"PUBLIC":       true,
+	"CURRENT_ROLE": true,
+	"CURRENT_USER": true,
+	"SESSION_USER": true,
+}
+
+// CreatePolicy generates "create policy %s on %s" statement for row level security policy
+// NOTE: Only supported by postgres. As option is only supported by postgres 10+
+func (b *Builder) CreatePolicy(name string, table string, opts PolicyOptions) *Builder {
+	if !b.supports(FeatureRowLevelSecurity) {
+		return b
+	}
+	if opts.As != "" && !b.supports(FeaturePolicyType) {
+		return b
+	}
+
+	b.query.AddClause(fmt.Sprintf("CREATE POLICY %s ON %s", b.adapter.Escape(name), b.table(table)))
+	if opts.As != "" {
+		b.query.AddClause(fmt.Sprintf("AS %s", opts.As))
+	}
+	if opts.Command != "" {
+		b.query.AddClause(fmt.Sprintf("FOR %s", opts.Command))
+	}
+	if len(opts.Roles) > 0 {
+		roles := []string{}
+		for _, r := range opts.Roles {
+			if policyRoles[strings.ToUpper(r)] {
+				roles = append(roles, strings.ToUpper(r))
+				continue
+			}
+			roles = append(roles, b.adapter.Escape(r))
+		}
+		b.query.AddClause(fmt.Sprintf("TO %s", strings.Join(roles, ", ")))
+	}
+	if opts.UsingExpr != "" {
+		b.query.AddClause(fmt.Sprintf("USING (%s)", opts.UsingExpr))
+	}
+	if opts.CheckExpr != "" {
+		b.query.AddClause(fmt.Sprintf("WITH CHECK (%s)", opts.CheckExpr))
+	}
+	return b
+}
+
+// InheritTable generates "alter table %s inherit %s" statement adding parent to child table
+// NOTE: Only supported by postgres
+func (b *Builder) InheritTable(parent string, child string) *Builder {
+	if !b.supports(FeatureInheritance) {
+		return b
+	}
+	b.alterTable(b.table(child))
+	b.query.AddClause(fmt.Sprintf("INHERIT %s", b.table(parent)))
+	return b
+}
+
+// NoInheritTable generates "alter table %s no inherit %s" statement removing parent from child table
+// NOTE: Only supported by postgres
+func (b *Builder) NoInheritTable(parent string, child string) *Builder {
+	if !b.supports(FeatureInheritance) {
+		return b
+	}
+	b.alterTable(b.table(child))
+	b.query.AddClause(fmt.Sprintf("NO INHERIT %s", b.table(parent)))
+	return b
+}
+
+// PartitionBound is the "for values" clause of a partition
+// Values are sql expressions such as "'2024-01-01'" or "MINVALUE"
+// Modulus and Remainder are used for hash partitions, In for list partitions and From, To for range partitions
+type PartitionBound struct {
+	From      []string
+	To        []string
+	In        []string
+	Modulus   int
+	Remainder int
+}
+
+// SQL returns the partition bound as an sql statement
+func (p PartitionBound) SQL() string {
+	if p.Modulus > 0 {
+		return fmt.Sprintf("FOR VALUES WITH (MODULUS %d, REMAINDER %d)", p.Modulus, p.Remainder)
+	}
+	if len(p.In) > 0 {
+		return fmt.Sprintf("FOR VALUES IN (%s)", strings.Join(p.In, ", "))
+	}
+	return fmt.Sprintf("FOR VALUES FROM (%s) TO (%s)", strings.Join(p.From, ", "), strings.Join(p.To, ", "))
+}
+
+// AttachPartition generates "alter table %s attach partition %s for values %s" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) AttachPartition(parent string, child string, bound PartitionBound) *Builder {
+	if !b.supports(FeaturePartitioning) {
+		return b
+	}
+	b.alterTable(b.table(parent))
+	b.query.AddClause(fmt.Sprintf("ATTACH PARTITION %s %s", b.table(child), bound.SQL()))
+	return b
+}
+
+// DetachPartition generates "alter table %s detach partition %s" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) DetachPartition(parent string, child string) *Builder {
+	if !b.supports(FeaturePartitioning) {
+		return b
+	}
+	b.alterTable(b.table(parent))
+	b.query.AddClause(fmt.Sprintf("DETACH PARTITION %s", b.table(child)))
+	return b
+}
+
+// PartitionStrategy is the "partition by" clause of a partitioned table
+type PartitionStrategy struct {
+	// Method is one of range, list or hash
+	Method  string
+	Columns []string
+}
+
+// CreatePartitionedTable generates "create table %s (%s) partition by %s (%s)" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) CreatePartitionedTable(table string, partitionBy PartitionStrategy, fields []string, constraints []string) *Builder {
+	if !b.supports(FeaturePartitioning) {
+		return b
+	}
+	b.CreateTable(table, fields, constraints)
+	b.query.clauses[len(b.query.clauses)-1] = fmt.Sprintf(
+		") PARTITION BY %s (%s)",
+		strings.ToUpper(partitionBy.Method),
+		strings.Join(b.adapter.EscapeAll(partitionBy.Columns), ", "),
+	)
+	return b
+}
+
+// CreateTablePartition generates "create table %s partition of %s for values %s" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) CreateTablePartition(partitionName string, parentName string, bound PartitionBound) *Builder {
+	if !b.supports(FeaturePartitioning) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE TABLE %s PARTITION OF %s %s", b.table(partitionName), b.table(parentName), bound.SQL()))
+	return b
+}
+
+// AlterTableSetUnlogged generates "alter table %s set unlogged" statement disabling write ahead logging of table
+// NOTE: Only supported by postgres
+func (b *Builder) AlterTableSetUnlogged(table string) *Builder {
+	if !b.supports(FeatureUnlogged) {
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause("SET UNLOGGED")
+	return b
+}
+
+// AlterTableSetLogged generates "alter table %s set logged" statement
+// NOTE: Only supported by postgres
+func (b *Builder) AlterTableSetLogged(table string) *Builder {
+	if !b.supports(FeatureUnlogged) {
+		return b
+	}
+	b.alterTable(b.table(table))
+	b.query.AddClause("SET LOGGED")
+	return b
+}
+
+// CreateExtension generates "create extension %s" statement
+// Extension names are always quoted since they may contain characters such as "-"
+// NOTE: Only supported by postgres
+func (b *Builder) CreateExtension(name string) *Builder {
+	if !b.supports(FeatureExtensions) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE EXTENSION %s", b.adapter.QuoteIdentifier(name)))
+	return b
+}
+
+// CreateExtensionIfNotExists generates "create extension if not exists %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) CreateExtensionIfNotExists(name string) *Builder {
+	if !b.supports(FeatureExtensions) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s", b.adapter.QuoteIdentifier(name)))
+	return b
+}
+
+// DropExtension generates "drop extension %s" statement
+// NOTE: Only supported by postgres
+func (b *Builder) DropExtension(name string) *Builder {
+	if !b.supports(FeatureExtensions) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("DROP EXTENSION %s", b.adapter.QuoteIdentifier(name)))
+	return b
+}
+
+// CollationOptions is the set of options of create collation statement
+type CollationOptions struct {
+	// Provider is one of icu or libc
+	Provider string
+	Locale   string
+	// Deterministic is omitted if nil, postgres defaults to deterministic collations
+	Deterministic *bool
+}
+
+// CreateCollation generates "create collation %s (%s)" statement
+// NOTE: Only supported by postgres
+func (b *Builder) CreateCollation(name string, opts CollationOptions) *Builder {
+	if !b.supports(FeatureCollations) {
+		return b
+	}
+
+	options := []string{}
+	if opts.Provider != "" {
+		options = append(options, fmt.Sprintf("PROVIDER = %s", opts.Provider))
+	}
+	if opts.Locale != "" {
+		options = append(options, fmt.Sprintf("LOCALE = %s", b.adapter.QuoteLiteral(opts.Locale)))
+	}
+	if opts.Deterministic != nil {
+		options = append(options, fmt.Sprintf("DETERMINISTIC = %t", *opts.Deterministic))
+	}
+
+	b.query.AddClause(fmt.Sprintf("CREATE COLLATION %s (%s)", b.adapter.Escape(name), strings.Join(options, ", ")))
+	return b
+}
+
+// CreatePublication generates "create publication %s for table %s" statement
+// If no tables are given, an empty publication is created
+// NOTE: Only supported by postgres 10+
+func (b *Builder) CreatePublication(name string, tables ...string) *Builder {
+	if !b.supports(FeatureLogicalReplication) {
+		return b
+	}
+	if len(tables) == 0 {
+		b.query.AddClause(fmt.Sprintf("CREATE PUBLICATION %s", b.adapter.Escape(name)))
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("CREATE PUBLICATION %s FOR TABLE %s", b.adapter.Escape(name), strings.Join(b.tables(tables), ", ")))
+	return b
+}
+
+// AlterPublication generates "alter publication %s add table %s" or "alter publication %s drop table %s" statement
+// Since a publication can't add and drop tables in a single statement,
+// giving both addTables and dropTables is an error and they should be altered in separate calls
+// NOTE: Only supported by postgres 10+
+func (b *Builder) AlterPublication(name string, addTables []string, dropTables []string) *Builder {
+	if !b.supports(FeatureLogicalReplication) {
+		return b
+	}
+	if len(addTables) > 0 && len(dropTables) > 0 {
+		b.query.AddError(fmt.Errorf("Publication %s can't add and drop tables in a single statement", name))
+		return b
+	}
+	if len(addTables) > 0 {
+		b.query.AddClause(fmt.Sprintf("ALTER PUBLICATION %s ADD TABLE %s", b.adapter.Escape(name), strings.Join(b.tables(addTables), ", ")))
+	}
+	if len(dropTables) > 0 {
+		b.query.AddClause(fmt.Sprintf("ALTER PUBLICATION %s DROP TABLE %s", b.adapter.Escape(name), strings.Join(b.tables(dropTables), ", ")))
+	}
+	return b
+}
+
+// DropPublication generates "drop publication %s" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) DropPublication(name string) *Builder {
+	if !b.supports(FeatureLogicalReplication) {
+		return b
+	}
+	b.query.AddClause(fmt.Sprintf("DROP PUBLICATION %s", b.adapter.Escape(name)))
+	return b
+}
+
+// tables escapes each table and adds table prefix
+func (b *Builder) tables(tables []string) []string {
+	tbls := []string{}
+	for _, t := range tables {
+		tbls = append(tbls, b.table(t))
+	}
+	return tbls
+}
+
+// SubscriptionOptions is the set of options of create subscription statement
+type SubscriptionOptions struct {
+	SlotName string
+	// SynchronousCommit is one of on, off, local, remote_write or remote_apply
+	SynchronousCommit string
+	// CreateSlot is omitted if nil, postgres defaults to creating the replication slot
+	CreateSlot *bool
+}
+
+// CreateSubscription generates "create subscription %s connection '%s' publication %s" statement
+// NOTE: Only supported by postgres 10+
+func (b *Builder) CreateSubscription(name string, conninfo string, publications ...string) *Builder {
+	return b.CreateSubscriptionWithOptions(name, conninfo, publications, SubscriptionOptions{})
+}
+
+// CreateSubscriptionWithOptions generates "create subscription %s connection '%s' publication %s with (%s)" statement
+// Unless CreateSlot is false, the query is not transactional since creating the replication slot
+// can't run inside a transaction block, therefore sessions reject it and it should be executed using the engine
+// NOTE: Only supported by postgres 10+
+func (b *Builder) CreateSubscriptionWithOptions(name string, conninfo string, publications []string, opts SubscriptionOptions) *Builder {
+	if !b.supports(FeatureLogicalReplication) {
+		return b
+	}
+	if opts.CreateSlot == nil || *opts.CreateSlot {
+		b.query.nonTransactional = true
+	}
+
+	b.query.AddClause(fmt.Sprintf("CREATE SUBSCRIPTION %s", b.adapter.Escape(name)))
+	b.query.AddClause(fmt.Sprintf("CONNECTION %s", b.adapter.QuoteLiteral(conninfo)))
+	b.query.AddClause(fmt.Sprintf("PUBLICATION %s", strings.Join(b.adapter.EscapeAll(publications), ", ")))
+
+	options := []string{}
+	if opts.SlotName != "" {
+		options = append(options, fmt.Sprintf("slot_name = %s", b.adapter.QuoteLiteral(opts.SlotName)))
+	}
+	if opts.SynchronousCommit != "" {
+		options = append(options, fmt.Sprintf("synchronous_commit = %s", b.adapter.QuoteLiteral(opts.SynchronousCommit)))
+	}
+	if opts.CreateSlot != nil {
+		options = append(options, fmt.Sprintf("create_slot = %t", *opts.CreateSlot))
+	}
+	if len(options) > 0 {
+		b.query.AddClause(fmt.Sprintf("WITH (%s)", strings.Join(options, ", ")))
+	}
+	return b
+}
+
+// column definitions
+
+// AutoIncrementPK returns the auto increment primary key column definition of the adapter for column
+func (b *Builder) AutoIncrementPK(colName string) string {
+	switch b.adapter.Driver() {
+	case "postgres":
+		return fmt.Sprintf("%s SERIAL PRIMARY KEY", b.adapter.Escape(colName))
+	case "sqlite3":
+		return fmt.Sprintf("%s INTEGER PRIMARY KEY AUTOINCREMENT", b.adapter.Escape(colName))
+	}
+	return fmt.Sprintf("%s INT AUTO_INCREMENT PRIMARY KEY", b.adapter.Escape(colName))
+}
+
+// CreatedAtColumn returns the creation timestamp column definition of the adapter for column
+func (b *Builder) CreatedAtColumn(name string) string {
+	return fmt.Sprintf("%s %s DEFAULT %s", b.adapter.Escape(name), b.timestampType(), b.DefaultValue("TIMESTAMP"))
+}
+
+// UpdatedAtColumn returns the update timestamp column definition of the adapter for column
+// mysql also updates the column on each update whereas postgres and sqlite require a trigger
+func (b *Builder) UpdatedAtColumn(name string) string {
+	column := b.CreatedAtColumn(name)
+	if b.adapter.Driver() == "mysql" {
+		column += " ON UPDATE CURRENT_TIMESTAMP"
+	}
+	return column
+}
+
+// timestampType returns the time zone aware timestamp type of the adapter
+func (b *Builder) timestampType() string {
+	if b.adapter.Driver() == "postgres" {
+		return "TIMESTAMP WITH TIME ZONE"
+	}
+	return "DATETIME"
+}
+
+// SoftDeleteColumn returns the nullable deletion timestamp column definition of the adapter for column
+func (b *Builder) SoftDeleteColumn(name string) string {
+	return fmt.Sprintf("%s %s NULL", b.adapter.Escape(name), b.timestampType())
+}
+
+// UUIDPrimaryKey returns the uuid primary key column definition of the adapter for column
+// postgres generates the default value using GenUUID whereas mysql and sqlite expect the application to generate it
+func (b *Builder) UUIDPrimaryKey(colName string) string {
+	switch b.adapter.Driver() {
+	case "postgres":
+		return fmt.Sprintf("%s UUID PRIMARY KEY DEFAULT %s", b.adapter.Escape(colName), b.GenUUID())
+	case "sqlite3":
+		return fmt.Sprintf("%s TEXT PRIMARY KEY", b.adapter.Escape(colName))
+	}
+	return fmt.Sprintf("%s CHAR(36) PRIMARY KEY", b.adapter.Escape(colName))
+}
+
+// JsonColumn returns the json column definition of the adapter for column
+// postgres generates jsonb, mysql generates json and sqlite generates text
+func (b *Builder) JsonColumn(name string) string {
+	switch b.adapter.Driver() {
+	case "postgres":
+		return fmt.Sprintf("%s JSONB", b.adapter.Escape(name))
+	case "sqlite3":
+		return fmt.Sprintf("%s TEXT", b.adapter.Escape(name))
+	}
+	return fmt.Sprintf("%s JSON", b.adapter.Escape(name))
+}
+
+// JsonbColumn returns "%s jsonb" column definition for column
+// NOTE: Only supported by postgres
+func (b *Builder) JsonbColumn(name string) string {
+	if !b.supports(FeatureJSONB) {
+		return ""
+	}
+	return fmt.Sprintf("%s JSONB", b.adapter.Escape(name))
+}
+
+// TextArrayColumn returns "%s text[] not null default '{}'" column definition for column
+// NOTE: Only supported by postgres
+func (b *Builder) TextArrayColumn(name string) string {
+	return b.ArrayColumn(name, "TEXT")
+}
+
+// IntArrayColumn returns "%s integer[] not null default '{}'" column definition for column
+// NOTE: Only supported by postgres
+func (b *Builder) IntArrayColumn(name string) string {
+	return b.ArrayColumn(name, "INTEGER")
+}
+
+// UUIDArrayColumn returns "%s uuid[] not null default '{}'" column definition for column
+// NOTE: Only supported by postgres
+func (b *Builder) UUIDArrayColumn(name string) string {
+	return b.ArrayColumn(name, "UUID")
+}
+
+// ArrayColumn returns "%s %s[] not null default '{}'" column definition for column and element type
+// NOTE: Only supported by postgres
+func (b *Builder) ArrayColumn(name string, elementType string) string {
+	if !b.supports(FeatureArrays) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%s %s[] NOT NULL DEFAULT '{}'::%s[]",
+		b.adapter.Escape(name),
+		strings.ToUpper(elementType),
+		strings.ToLower(elementType),
+	)
+}
+
+// PointColumn returns "%s geometry(point, 4326)" column definition for column
+// NOTE: Only supported by postgres with postgis extension installed
+func (b *Builder) PointColumn(name string) string {
+	if !b.supports(FeatureSpatial) {
+		return ""
+	}
+	return fmt.Sprintf("%s GEOMETRY(POINT, 4326)", b.adapter.Escape(name))
+}
+
+// GeographyColumn returns "%s geography(%s, %d)" column definition for column, geometry type and srid
+// NOTE: Only supported by postgres with postgis extension installed
+func (b *Builder) GeographyColumn(name string, geometryType string, srid int) string {
+	if !b.supports(FeatureSpatial) {
+		return ""
+	}
+	return fmt.Sprintf("%s GEOGRAPHY(%s, %d)", b.adapter.Escape(name), strings.ToUpper(geometryType), srid)
+}
+
+// VectorColumn returns "%s vector(%d)" column definition for column and dimensions
+// NOTE: Only supported by postgres with pgvector extension installed
+func (b *Builder) VectorColumn(name string, dimensions int) string {
+	if !b.supports(FeatureVector) {
+		return ""
+	}
+	return fmt.Sprintf("%s vector(%d)", b.adapter.Escape(name), dimensions)
+}
+
+// CosineDistance function generates "%s <=> placeholder::vector" for column and adds binding for value
+// NOTE: Only supported by postgres with pgvector extension installed
+func (b *Builder) CosineDistance(col string, value interface{}) string {
+	return b.vectorDistance("<=>", col, value)
+}
+
+// L2Distance function generates "%s <-> placeholder::vector" for column and adds binding for value
+// NOTE: Only supported by postgres with pgvector extension installed
+func (b *Builder) L2Distance(col string, value interface{}) string {
+	return b.vectorDistance("<->", col, value)
+}
+
+// vectorDistance converts float slices to vector literals such as "[1,2,3]" since pq doesn't support vectors
+func (b *Builder) vectorDistance(op string, col string, value interface{}) string {
+	if !b.supports(FeatureVector) {
+		return ""
+	}
+
+	elems := []string{}
+	switch v := value.(type) {
+	case []float32:
+		for _, e := range v {
+			elems = append(elems, strconv.FormatFloat(float64(e), 'f', -1, 32))
+		}
+		value = fmt.Sprintf("[%s]", strings.Join(elems, ","))
+	case []float64:
+		for _, e := range v {
+			elems = append(elems, strconv.FormatFloat(e, 'f', -1, 64))
+		}
+		value = fmt.Sprintf("[%s]", strings.Join(elems, ","))
+	}
+
+	b.query.AddBinding(value)
+	return fmt.Sprintf("%s %s %s::vector", b.key(col), op, b.adapter.Placeholder())
+}
@@ -0,0 +1,34 @@
+package qb
+
+// NewBuilderGroup generates a new builder group
+func NewBuilderGroup() *BuilderGroup {
+	return &BuilderGroup{
+		queries: []*Query{},
+		errors:  []error{},
+	}
+}
+
+// BuilderGroup collects the queries of multiple independent builders in order
+// It is useful for schema setups & migrations that consist of many statements
+type BuilderGroup struct {
+	queries []*Query
+	errors  []error
+}
+
+// Add calls Query() of the builder and appends the query and its errors to the group
+func (g *BuilderGroup) Add(b *Builder) *BuilderGroup {
+	query := b.Query()
+	g.queries = append(g.queries, query)
+	g.errors = append(g.errors, query.Errors()...)
+	return g
+}
+
+// Queries returns all queries of the group in the order they are added
+func (g *BuilderGroup) Queries() []*Query {
+	return g.queries
+}
+
+// Errors returns all errors of the queries in the group
+func (g *BuilderGroup) Errors() []error {
+	return g.errors
+}
@@ -0,0 +1,22 @@
+package qb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBuilderGroup(t *testing.T) {
+	b := NewBuilder("sqlite3")
+
+	group := NewBuilderGroup()
+	group.
+		Add(b.CreateTable("user", []string{"id INTEGER PRIMARY KEY"}, []string{})).
+		Add(b.CreateIndex("index_user_id", "user", "id")).
+		Add(b.Select("id").From("user").GroupByCube("id"))
+
+	assert.Equal(t, len(group.Queries()), 3)
+	assert.Equal(t, group.Queries()[0].SQL(), "CREATE TABLE user(\n\tid INTEGER PRIMARY KEY\n);")
+	assert.Equal(t, group.Queries()[1].SQL(), "CREATE INDEX index_user_id ON user(id);")
+	assert.Equal(t, group.Queries()[2].SQL(), "SELECT id\nFROM user;")
+	assert.Equal(t, len(group.Errors()), 1)
+}
@@ -1,10 +1,16 @@
 package qb
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"log"
+	"math"
+	"strings"
 	"testing"
+	"time"
 )
 
 type BuilderTestSuite struct {
@@ -180,10 +186,36 @@ func (suite *BuilderTestSuite) TestBuilderBasicInsert() {
 	assert.Contains(suite.T(), query.SQL(), "email")
 	assert.Contains(suite.T(), query.SQL(), "password")
 	assert.Contains(suite.T(), query.SQL(), "\nVALUES (?, ?, ?)")
-	assert.Contains(suite.T(), query.SQL(), "RETURNING email;")
 	assert.Contains(suite.T(), query.Bindings(), "Aras Can Akin")
 	assert.Contains(suite.T(), query.Bindings(), "a@b.c")
 	assert.Contains(suite.T(), query.Bindings(), "p4ssw0rd")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+	assert.Equal(suite.T(), query.Errors()[0].Error(), "RETURNING is not supported by mysql driver")
+}
+
+func (suite *BuilderTestSuite) TestBuilderReturning() {
+	query := suite.builder.
+		Insert("user").
+		Values(map[string]interface{}{"email": "a@b.c"}).
+		Returning("id").
+		Query()
+
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.
+		Insert("user").
+		Values(map[string]interface{}{"email": "a@b.c"}).
+		Returning("id", "email").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "INSERT INTO user\n(email)\nVALUES ($1)\nRETURNING id, email;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"a@b.c"})
+
+	b = NewBuilder("sqlite3")
+	b.Adapter().SetVersion("3.34")
+	query = b.Delete("user").Returning("id").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
 }
 
 func (suite *BuilderTestSuite) TestBuilderBasicUpdate() {
@@ -257,12 +289,21 @@ func (suite *BuilderTestSuite) TestBuilderFullOuterJoin() {
 		Select("id", "name", "email").
 		From("user").
 		FullOuterJoin("email e", "user.id = e.id").
-		Where("id = ?", 5).
 		Query()
 
-	assert.Equal(suite.T(), query.SQL(), "SELECT id, name, email\nFROM user\nFULL OUTER JOIN email e ON user.id = e.id\nWHERE id = ?;")
-	assert.Equal(suite.T(), query.Bindings(), []interface{}{5})
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+	assert.Equal(suite.T(), query.Errors()[0].Error(), "FULL OUTER JOIN is not supported by mysql driver")
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select("id", "name", "email").
+		From("user").
+		FullOuterJoin("email e", "user.id = e.id").
+		Where(b.Eq("id", 5)).
+		Query()
 
+	assert.Equal(suite.T(), query.SQL(), "SELECT id, name, email\nFROM user\nFULL OUTER JOIN email e ON user.id = e.id\nWHERE id = $1;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{5})
 }
 
 func (suite *BuilderTestSuite) TestBuilderCrossJoin() {
@@ -337,6 +378,1745 @@ func (suite *BuilderTestSuite) TestBuilderCreateIndex() {
 	assert.Equal(suite.T(), query.SQL(), "CREATE INDEX index_user_id ON user(id);")
 }
 
-func TestBuilderSuite(t *testing.T) {
-	suite.Run(t, new(BuilderTestSuite))
+func (suite *BuilderTestSuite) TestBuilderGroupByRollup() {
+	query := suite.builder.
+		Select("category", "brand", suite.builder.Sum("price")).
+		From("products").
+		GroupByRollup("category", "brand").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT category, brand, SUM(price)\nFROM products\nGROUP BY category, brand WITH ROLLUP;")
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderGroupBySets() {
+	query := suite.builder.
+		Select("category", "brand").
+		From("products").
+		GroupBySets([][]string{{"category", "brand"}}).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT category, brand\nFROM products;")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select("category", "brand", b.Sum("price")).
+		From("products").
+		GroupBySets([][]string{{"category", "brand"}, {"category"}, {"brand"}, {}}).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT category, brand, SUM(price)\nFROM products\nGROUP BY GROUPING SETS ((category, brand), (category), (brand), ());")
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+
+	query = b.Select("category").From("products").GroupByRollup("category").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT category\nFROM products\nGROUP BY ROLLUP (category);")
+
+	query = b.Select("category").From("products").GroupByCube("category").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT category\nFROM products\nGROUP BY CUBE (category);")
+
+	b = NewBuilder("sqlite3")
+	query = b.Select("category").From("products").GroupByCube("category").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT category\nFROM products;")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderFilter() {
+	query := suite.builder.
+		Select(suite.builder.Filter(suite.builder.Count("id"), "price > ?", 50)).
+		From("products").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT COUNT(id)\nFROM products;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{})
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select(b.Filter(b.Count("id"), fmt.Sprintf("price > %s", b.Adapter().Placeholder()), 50)).
+		From("products").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT COUNT(id) FILTER (WHERE price > $1)\nFROM products;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{50})
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderPivot() {
+	query := suite.builder.
+		Select("region").
+		From("sales").
+		Where("year = ?", 2016).
+		Pivot("amount", "quarter", []string{"Q1", "Q2"}, "SUM").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT region, SUM(CASE WHEN quarter = 'Q1' THEN amount END) AS `Q1`, SUM(CASE WHEN quarter = 'Q2' THEN amount END) AS `Q2`\nFROM sales\nWHERE year = ?\nGROUP BY region;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{2016})
+
+	query = suite.builder.
+		Select("region", "COUNT(*) AS total").
+		From("sales").
+		Pivot("amount", "quarter", []string{"first quarter", "it's"}, "SUM", "region").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT region, COUNT(*) AS total, SUM(CASE WHEN quarter = 'first quarter' THEN amount END) AS `first quarter`, SUM(CASE WHEN quarter = 'it''s' THEN amount END) AS `it's`\nFROM sales\nGROUP BY region;")
+
+	query = suite.builder.
+		Select("region", "COUNT(*) AS total").
+		From("sales").
+		Pivot("amount", "quarter", []string{"Q1"}, "SUM").
+		Query()
+
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	query = suite.builder.
+		Pivot("amount", "quarter", []string{"Q1"}, "SUM").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderUnpivot() {
+	query := suite.builder.
+		Unpivot("sales", "amount", "quarter", []string{"q1", "q2"}).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT 'q1' AS quarter, q1 AS amount\nFROM sales\nUNION ALL\nSELECT 'q2' AS quarter, q2 AS amount\nFROM sales;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderWith() {
+	active := NewBuilder("mysql")
+	active.Select("id").From("user").Where("active = ?", true)
+
+	admin := NewBuilder("mysql")
+	admin.Select("id").From("user").Where("role = ?", "admin")
+
+	query := suite.builder.
+		With("active_users", active).
+		With("admin_users", admin).
+		Select("id").
+		From("active_users").
+		Where("id IN (SELECT id FROM admin_users) AND id > ?", 5).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "WITH active_users AS (SELECT id\nFROM user\nWHERE active = ?), admin_users AS (SELECT id\nFROM user\nWHERE role = ?)\nSELECT id\nFROM active_users\nWHERE id IN (SELECT id FROM admin_users) AND id > ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{true, "admin", 5})
+
+	joined := NewBuilder("mysql")
+	joined.Select("u.id").From("user u").FullOuterJoin("email e", "u.id = e.user_id")
+
+	query = suite.builder.
+		With("joined", joined).
+		Select("id").
+		From("joined").
+		Query()
+
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+	assert.Equal(suite.T(), query.Errors()[0].Error(), "FULL OUTER JOIN is not supported by mysql driver")
+}
+
+func (suite *BuilderTestSuite) TestBuilderWithPostgres() {
+	b := NewBuilder("postgres")
+
+	first := NewBuilder("postgres")
+	first.Select("id").From("user").Where(first.Eq("active", true))
+
+	second := NewBuilder("postgres")
+	second.Select("id").From("user").Where(second.Eq("role", "admin"))
+
+	query := b.
+		With("active_users", first).
+		With("admin_users", second).
+		Select("id").
+		From("active_users").
+		Where(b.Gt("id", 5)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "WITH active_users AS (SELECT id\nFROM user\nWHERE active = $1), admin_users AS (SELECT id\nFROM user\nWHERE role = $2)\nSELECT id\nFROM active_users\nWHERE id > $3;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{true, "admin", 5})
+}
+
+func (suite *BuilderTestSuite) TestBuilderWithMaterialized() {
+	inner := NewBuilder("mysql")
+	inner.Select("id").From("user")
+
+	query := suite.builder.
+		WithMaterialized("users", inner).
+		Select("id").
+		From("users").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM users;")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	first := NewBuilder("postgres")
+	first.Select("id").From("user")
+
+	second := NewBuilder("postgres")
+	second.Select("id").From("admin")
+
+	query = b.
+		WithMaterialized("users", first).
+		WithNotMaterialized("admins", second).
+		Select("id").
+		From("users").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "WITH users AS MATERIALIZED (SELECT id\nFROM user), admins AS NOT MATERIALIZED (SELECT id\nFROM admin)\nSELECT id\nFROM users;")
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderWritableCTE() {
+	dml := NewBuilder("postgres")
+	dml.Delete("orders").Where(dml.St("created_at", "2016-01-01")).Returning("*")
+
+	b := NewBuilder("postgres")
+	query := b.
+		WritableCTE("moved", dml).
+		Insert("archived_orders").
+		Select("*").
+		From("moved").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "WITH moved AS (DELETE FROM orders\nWHERE created_at < $1\nRETURNING *)\nINSERT INTO archived_orders\nSELECT *\nFROM moved;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"2016-01-01"})
+
+	dml = NewBuilder("mysql")
+	dml.Delete("orders")
+
+	query = suite.builder.WritableCTE("moved", dml).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderInterpolate() {
+	sql, bindings, err := suite.builder.Interpolate("SELECT * FROM user WHERE email = :email AND age > :age", map[string]interface{}{
+		"email": "a@b.c",
+		"age":   18,
+	})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), sql, "SELECT * FROM user WHERE email = ? AND age > ?")
+	assert.Equal(suite.T(), bindings, []interface{}{"a@b.c", 18})
+
+	b := NewBuilder("postgres")
+	sql, bindings, err = b.Interpolate("SELECT * FROM user WHERE id = :id::int OR parent_id = :id", map[string]interface{}{"id": "5"})
+
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), sql, "SELECT * FROM user WHERE id = $1::int OR parent_id = $2")
+	assert.Equal(suite.T(), bindings, []interface{}{"5", "5"})
+
+	sql, bindings, err = b.Interpolate("SELECT * FROM user WHERE at = '12:30' AND note = 'it''s :id' AND id = :id", map[string]interface{}{"id": 5})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), sql, "SELECT * FROM user WHERE at = '12:30' AND note = 'it''s :id' AND id = $1")
+	assert.Equal(suite.T(), bindings, []interface{}{5})
+
+	sql, _, err = suite.builder.Interpolate(`SELECT * FROM user WHERE note = 'it\'s :id' AND id = :id`, map[string]interface{}{"id": 5})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), sql, `SELECT * FROM user WHERE note = 'it\'s :id' AND id = ?`)
+
+	sql, _, err = b.Interpolate("SELECT * FROM user WHERE id = :id", map[string]interface{}{"id": 5})
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), sql, "SELECT * FROM user WHERE id = $1")
+
+	query := b.Select("id").From("user").Where(b.Eq("email", "a@b.c")).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email = $1;")
+
+	_, _, err = suite.builder.Interpolate("SELECT * FROM user WHERE id = :id", map[string]interface{}{})
+	assert.NotNil(suite.T(), err)
+}
+
+func (suite *BuilderTestSuite) TestBuilderSelectAggregates() {
+	query := suite.builder.SelectCount("id").From("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT COUNT(id)\nFROM user;")
+
+	query = suite.builder.SelectSum("price").From("products").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT SUM(price)\nFROM products;")
+
+	query = suite.builder.SelectAvg("price").From("products").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT AVG(price)\nFROM products;")
+
+	query = suite.builder.SelectMin("price").From("products").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT MIN(price)\nFROM products;")
+
+	query = suite.builder.SelectMax("price").From("products").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT MAX(price)\nFROM products;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderWhereInNotIn() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		WhereIn("name", "Aras", "Can").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE name IN (?,?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"Aras", "Can"})
+
+	query = suite.builder.
+		Select("id").
+		From("user").
+		WhereNotIn("email", "a@b.c").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email NOT IN (?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"a@b.c"})
+}
+
+func (suite *BuilderTestSuite) TestBuilderWhereBetween() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		WhereBetween("age", 18, 35).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age BETWEEN ? AND ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{18, 35})
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select("id").
+		From("user").
+		WhereNotBetween("age", 18, 35).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age NOT BETWEEN $1 AND $2;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{18, 35})
+}
+
+func (suite *BuilderTestSuite) TestBuilderWhereComparisons() {
+	query := suite.builder.Select("id").From("user").WhereEq("email", "a@b.c").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email = ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"a@b.c"})
+
+	query = suite.builder.Select("id").From("user").WhereNotEq("email", "a@b.c").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email != ?;")
+
+	query = suite.builder.Select("id").From("user").WhereGt("age", 18).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age > ?;")
+
+	query = suite.builder.Select("id").From("user").WhereGte("age", 18).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age >= ?;")
+
+	query = suite.builder.Select("id").From("user").WhereLt("age", 35).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age < ?;")
+
+	query = suite.builder.Select("id").From("user").WhereLte("age", 35).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE age <= ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{35})
+}
+
+func (suite *BuilderTestSuite) TestBuilderWhereLike() {
+	query := suite.builder.Select("id").From("user").WhereLike("email", "%@b.c").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email LIKE ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"%@b.c"})
+
+	query = suite.builder.Select("id").From("user").WhereNotLike("email", "%@b.c").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email NOT LIKE ?;")
+
+	query = suite.builder.Select("id").From("user").WhereILike("email", "%@B.C").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{})
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.Select("id").From("user").WhereILike("email", "%@B.C").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email ILIKE $1;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"%@B.C"})
+
+	query = b.Select("id").From("user").WhereNotILike("email", "%@B.C").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE email NOT ILIKE $1;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderWhereIsNull() {
+	query := suite.builder.Select("id").From("user").WhereIsNull("deleted_at").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE deleted_at IS NULL;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{})
+
+	query = suite.builder.Select("id").From("user").WhereIsNotNull("deleted_at").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE deleted_at IS NOT NULL;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderAndWhere() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		AndWhere("active = ?", true).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE active = ?;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{true})
+
+	query = suite.builder.
+		Select("id").
+		From("user").
+		Where("active = ?", true).
+		AndWhere("age > ?", 18).
+		AndWhere(suite.builder.St("age", 35)).
+		OrderBy("id").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE (active = ?) AND (age > ?) AND (age < ?)\nORDER BY id;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{true, 18, 35})
+
+	query = suite.builder.
+		Select("id").
+		From("user").
+		Where("active = ?", true).
+		AndWhere("age > ?", 18).
+		Where("role = ? OR role = ?", "admin", "owner").
+		AndWhere("age < ?", 35).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE (active = ?) AND (age > ?)\nWHERE (role = ? OR role = ?) AND (age < ?);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderOrWhere() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		Where("role = ?", "admin").
+		OrWhere("role = ?", "owner").
+		OrWhere("role = ?", "editor").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE (role = ?) OR (role = ?) OR (role = ?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"admin", "owner", "editor"})
+
+	query = suite.builder.
+		Select("id").
+		From("user").
+		OrWhere("role = ?", "admin").
+		OrWhere("role = ?", "owner").
+		AndWhere("active = ?", true).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE ((role = ?) OR (role = ?)) AND (active = ?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"admin", "owner", true})
+
+	query = suite.builder.
+		Select("id").
+		From("user").
+		Where("role = ?", "admin").
+		OrWhere("role = ?", "owner").
+		Where("active = ? AND age > ?", true, 18).
+		OrWhere("role = ?", "editor").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE (role = ?) OR (role = ?)\nWHERE (active = ? AND age > ?) OR (role = ?);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderEqAny() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		Where(suite.builder.EqAny("id", 1, 2, 3)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE id IN (?,?,?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{1, 2, 3})
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select("id").
+		From("user").
+		Where(b.EqAny("id", 1, 2, 3)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE id = ANY($1);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{pq.Array([]interface{}{1, 2, 3})})
+}
+
+func (suite *BuilderTestSuite) TestBuilderNotEqAll() {
+	query := suite.builder.
+		Select("id").
+		From("user").
+		Where(suite.builder.NotEqAll("id", 1, 2)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE id NOT IN (?,?);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{1, 2})
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select("id").
+		From("user").
+		Where(b.NotEqAll("id", 1, 2)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nWHERE id != ALL($1);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{pq.Array([]interface{}{1, 2})})
+}
+
+func (suite *BuilderTestSuite) TestBuilderCoalesce() {
+	query := suite.builder.
+		Select(suite.builder.Coalesce("nickname", "name")).
+		From("user").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT COALESCE(nickname, name)\nFROM user;")
+
+	b := NewBuilder("postgres")
+	query = b.
+		Select(b.CoalesceV("discount", 0)).
+		From("products").
+		Where(b.Gt("price", 10)).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT COALESCE(discount, $1)\nFROM products\nWHERE price > $2;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{0, 10})
+}
+
+func (suite *BuilderTestSuite) TestBuilderConcat() {
+	query := suite.builder.
+		Select(suite.builder.ConcatV("first_name", " ", "last_name")).
+		From("user").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT CONCAT(first_name, ?, last_name)\nFROM user;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{" "})
+
+	query = suite.builder.
+		Select(suite.builder.ConcatV("u.first_name", "'); DROP TABLE user; --", 5)).
+		From("user u").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT CONCAT(u.first_name, ?, ?)\nFROM user u;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"'); DROP TABLE user; --", 5})
+
+	b := NewBuilder("sqlite3")
+	query = b.
+		Select(b.ConcatV("code", 1)).
+		From("products").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT (code || ?)\nFROM products;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{1})
+
+	b = NewBuilder("postgres")
+	assert.Equal(suite.T(), b.Concat("first_name", "last_name"), "CONCAT(first_name, last_name)")
+}
+
+func (suite *BuilderTestSuite) TestBuilderFunctionCall() {
+	b := NewBuilder("postgres")
+	query := b.
+		Select(b.FunctionCall("ROUND", "price", 2), b.FunctionCall("NOW")).
+		From("products").
+		Where(b.Eq("category", "books")).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT ROUND(price, $1), NOW()\nFROM products\nWHERE category = $2;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{2, "books"})
+}
+
+func (suite *BuilderTestSuite) TestBuilderOrderByCol() {
+	suite.builder.SetEscaping(true)
+	query := suite.builder.
+		Select("id").
+		From("user").
+		OrderByCol("created_at", Desc).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM `user`\nORDER BY `created_at` DESC;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderRandomOrder() {
+	query := suite.builder.Select("id").From("user").RandomOrder().Limit(0, 1).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY RAND()\nLIMIT 1 OFFSET 0;")
+
+	b := NewBuilder("postgres")
+	query = b.Select("id").From("user").RandomOrder().Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY RANDOM();")
+}
+
+func (suite *BuilderTestSuite) TestBuilderTablesample() {
+	query := suite.builder.Select("id").From("user").Tablesample("BERNOULLI", 5).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user;")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.Select("id").From("user u").Tablesample("SYSTEM", 2.5).Where(b.Eq("u.active", true)).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user u TABLESAMPLE SYSTEM(2.5)\nWHERE u.active = $1;")
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+
+	query = b.
+		Select("u.id").
+		From("user u").
+		Tablesample("SYSTEM", 10).
+		InnerJoin("session s", "u.id = s.user_id").
+		Tablesample("BERNOULLI", 1).
+		CrossJoin("region r").
+		Tablesample("SYSTEM", 50).
+		Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT u.id\n"+
+		"FROM user u TABLESAMPLE SYSTEM(10)\n"+
+		"INNER JOIN session s TABLESAMPLE BERNOULLI(1) ON u.id = s.user_id\n"+
+		"CROSS JOIN region r TABLESAMPLE SYSTEM(50);")
+
+	query = b.Select("u.id").From("user u").InnerJoin("session s", "u.id = s.user_id").Where(b.Eq("u.active", true)).Tablesample("SYSTEM", 5).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT u.id\nFROM user u\nINNER JOIN session s TABLESAMPLE SYSTEM(5) ON u.id = s.user_id\nWHERE u.active = $1;")
+
+	query = b.Select("id").Tablesample("SYSTEM", 2.5).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderNullsOrdering() {
+	query := suite.builder.Select("id").From("user").OrderBy(suite.builder.NullsFirst("last_login")).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY last_login;")
+
+	b := NewBuilder("postgres")
+	query = b.Select("id").From("user").OrderBy(b.NullsFirst("last_login"), b.NullsLast("created_at")).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY last_login NULLS FIRST, created_at NULLS LAST;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCollate() {
+	query := suite.builder.Select("id").From("user").OrderBy(suite.builder.Collate("name", "utf8mb4_unicode_ci")).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY name COLLATE `utf8mb4_unicode_ci`;")
+
+	b := NewBuilder("postgres")
+	query = b.Select("id").From("user").OrderBy(b.Collate("name", "en-US-x-icu")).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM user\nORDER BY name COLLATE \"en-US-x-icu\";")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCast() {
+	b := NewBuilder("postgres")
+	b.SetEscaping(true)
+	query := b.
+		Select("id").
+		From("user").
+		WhereGt(b.Cast("string_col", "integer"), 42).
+		AndWhere(b.Eq(b.Cast(b.Coalesce("age", "0"), "text"), "18")).
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM \"user\"\nWHERE (CAST(\"string_col\" AS integer) > $1) AND (CAST(COALESCE(age, 0) AS text) = $2);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{42, "18"})
+}
+
+func (suite *BuilderTestSuite) TestBuilderDistinct() {
+	b := NewBuilder("postgres")
+	b.SetEscaping(true)
+	assert.Equal(suite.T(), b.Count(b.Distinct("user_id")), "COUNT(DISTINCT \"user_id\")")
+	assert.Equal(suite.T(), b.Sum(b.Distinct("price")), "SUM(DISTINCT \"price\")")
+	assert.Equal(suite.T(), b.Avg("price"), "AVG(\"price\")")
+}
+
+func (suite *BuilderTestSuite) TestBuilderAggregateStar() {
+	suite.builder.SetEscaping(true)
+	query := suite.builder.
+		Select(suite.builder.Count(suite.builder.AggregateStar())).
+		From("user").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT COUNT(*)\nFROM `user`;")
+
+	query = suite.builder.SelectCount("*").From("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT COUNT(*)\nFROM `user`;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSelectRaw() {
+	query := suite.builder.SelectRaw("1", "NOW()").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT 1, NOW();")
+
+	query = suite.builder.
+		Select("id").
+		SelectRaw("'constant' AS label").
+		From("user").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT id, 'constant' AS label\nFROM user;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderJoinSchemaQualified() {
+	b := NewBuilder("postgres")
+	b.SetEscaping(true)
+
+	query := b.
+		Select("o.id").
+		From("public.users u").
+		InnerJoin("public.orders o", "u.id = o.user_id").
+		LeftOuterJoin("items", "o.id = items.order_id").
+		RightOuterJoin("payments p", "o.id = p.order_id").
+		FullOuterJoin("billing.invoices AS i", "o.id = i.order_id").
+		CrossJoin("public.regions").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT o.id\n"+
+		"FROM \"public\".\"users\" u\n"+
+		"INNER JOIN \"public\".\"orders\" o ON u.id = o.user_id\n"+
+		"LEFT OUTER JOIN \"items\" ON o.id = items.order_id\n"+
+		"RIGHT OUTER JOIN \"payments\" p ON o.id = p.order_id\n"+
+		"FULL OUTER JOIN \"billing\".\"invoices\" AS i ON o.id = i.order_id\n"+
+		"CROSS JOIN \"public\".\"regions\";")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSelfJoin() {
+	suite.builder.SetEscaping(true)
+	query := suite.builder.
+		Select("e.name", "m.name").
+		SelfJoin("employee", "e", "m", "e.manager_id = m.id").
+		Query()
+
+	assert.Equal(suite.T(), query.SQL(), "SELECT e.name, m.name\nFROM `employee` e\nINNER JOIN `employee` m ON e.manager_id = m.id;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateFullTextIndex() {
+	query := suite.builder.CreateFullTextIndex("index_post_body", "post", "title", "body").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE FULLTEXT INDEX index_post_body ON post(title,body);")
+
+	b := NewBuilder("postgres")
+	query = b.CreateFullTextIndex("index_post_body", "post", "title", "body").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE INDEX index_post_body ON post USING GIN(to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, '')));")
+
+	b = NewBuilder("sqlite3")
+	query = b.CreateFullTextIndex("index_post_body", "post", "body").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateIndexInclude() {
+	query := suite.builder.CreateIndexInclude("index_user_email", "user", []string{"email"}, []string{"name"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateIndexInclude("index_user_email", "user", []string{"email"}, []string{"name", "created_at"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE INDEX index_user_email ON user(email) INCLUDE (name,created_at);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateIndexConcurrently() {
+	query := suite.builder.CreateIndexConcurrently("index_user_email", "user", "email").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateIndexConcurrently("index_user_email", "user", "email", "name").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE INDEX CONCURRENTLY index_user_email ON user(email,name);")
+	assert.False(suite.T(), query.Transactional())
+
+	query = b.CreateIndex("index_user_email", "user", "email").Query()
+	assert.True(suite.T(), query.Transactional())
+}
+
+func (suite *BuilderTestSuite) TestBuilderAnalyzeVacuum() {
+	query := suite.builder.AnalyzeTable("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	b.SetEscaping(true)
+
+	query = b.AnalyzeTable("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "ANALYZE \"user\";")
+	assert.True(suite.T(), query.Transactional())
+
+	query = b.VacuumTable("user", VacuumOptions{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "VACUUM \"user\";")
+	assert.False(suite.T(), query.Transactional())
+
+	query = b.VacuumTable("user", VacuumOptions{Verbose: true, Analyze: true}).Query()
+	assert.Equal(suite.T(), query.SQL(), "VACUUM (VERBOSE, ANALYZE) \"user\";")
+}
+
+func (suite *BuilderTestSuite) TestBuilderReindex() {
+	query := suite.builder.Reindex("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.Reindex("user").Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX TABLE user;")
+
+	query = b.Reindex("INDEX index_user_id").Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX INDEX index_user_id;")
+	assert.True(suite.T(), query.Transactional())
+
+	query = b.Reindex("public", ReindexSchema, ReindexConcurrently).Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX SCHEMA CONCURRENTLY public;")
+	assert.False(suite.T(), query.Transactional())
+
+	query = b.Reindex("user", ReindexConcurrently).Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX TABLE CONCURRENTLY user;")
+	assert.False(suite.T(), query.Transactional())
+
+	query = b.Reindex("DATABASE qb_test").Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX DATABASE qb_test;")
+	assert.False(suite.T(), query.Transactional())
+
+	b.SetEscaping(true)
+
+	query = b.Reindex("INDEX public.index_user_id").Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX INDEX \"public\".\"index_user_id\";")
+
+	query = b.Reindex("public.user").Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX TABLE \"public\".\"user\";")
+
+	query = b.Reindex("public", ReindexSchema).Query()
+	assert.Equal(suite.T(), query.SQL(), "REINDEX SCHEMA \"public\";")
+
+	b.SetEscaping(false)
+
+	b.Adapter().SetVersion("11.2")
+	query = b.Reindex("user", ReindexConcurrently).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderSetSearchPath() {
+	query := suite.builder.SetSearchPath("tenant", "public").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.SetSearchPath("tenant", "public").Query()
+	assert.Equal(suite.T(), query.SQL(), "SET search_path TO tenant, public;")
+
+	query = b.SetConfig("statement_timeout", "5s").Query()
+	assert.Equal(suite.T(), query.SQL(), "SET statement_timeout TO '5s';")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSchema() {
+	query := suite.builder.CreateSchema("tenant").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE SCHEMA tenant;")
+
+	query = suite.builder.DropSchema("tenant", true).Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP SCHEMA tenant;")
+
+	b := NewBuilder("postgres")
+
+	query = b.CreateSchemaIfNotExists("tenant").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE SCHEMA IF NOT EXISTS tenant;")
+
+	query = b.DropSchema("tenant", true).Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP SCHEMA tenant CASCADE;")
+
+	query = b.DropSchema("tenant", false).Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP SCHEMA tenant;")
+
+	b = NewBuilder("sqlite3")
+	query = b.CreateSchema("tenant").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderGrantRevoke() {
+	b := NewBuilder("postgres")
+
+	query := b.Grant("SELECT, INSERT", "TABLE", "user", "app").Query()
+	assert.Equal(suite.T(), query.SQL(), "GRANT SELECT, INSERT ON TABLE user TO app;")
+
+	query = b.Revoke("INSERT", "TABLE", "user", "app").Query()
+	assert.Equal(suite.T(), query.SQL(), "REVOKE INSERT ON TABLE user FROM app;")
+
+	b.SetEscaping(true)
+
+	query = b.Grant("SELECT", "TABLE", "public.users", "app").Query()
+	assert.Equal(suite.T(), query.SQL(), "GRANT SELECT ON TABLE \"public\".\"users\" TO app;")
+
+	query = b.Revoke("SELECT", "TABLE", "public.users", "app").Query()
+	assert.Equal(suite.T(), query.SQL(), "REVOKE SELECT ON TABLE \"public\".\"users\" FROM app;")
+
+	b = NewBuilder("mysql")
+	b.SetEscaping(true)
+	query = b.Grant("SELECT", "TABLE", "app.*", "'app'@'%'").Query()
+	assert.Equal(suite.T(), query.SQL(), "GRANT SELECT ON TABLE `app`.* TO 'app'@'%';")
+
+	b = NewBuilder("sqlite3")
+	query = b.Grant("SELECT", "TABLE", "user", "app").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderRole() {
+	query := suite.builder.CreateRole("app", RoleOptions{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.CreateRole("readonly", RoleOptions{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE ROLE readonly;")
+
+	limit := 10
+	query = b.CreateRole("app", RoleOptions{Login: true, CreateDB: true, ConnectionLimit: &limit, Password: "p4ss'w0rd"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE ROLE app WITH LOGIN CREATEDB CONNECTION LIMIT 10 PASSWORD 'p4ss''w0rd';")
+
+	inherit := false
+	limit = 0
+	query = b.CreateRole("locked", RoleOptions{Inherit: &inherit, ConnectionLimit: &limit}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE ROLE locked WITH NOINHERIT CONNECTION LIMIT 0;")
+
+	inherit = true
+	query = b.CreateRole("member", RoleOptions{Inherit: &inherit}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE ROLE member WITH INHERIT;")
+
+	query = b.DropRole("app").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP ROLE app;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderComment() {
+	query := suite.builder.CommentOnTable("user", "users").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.CommentOnTable("user", "registered users").Query()
+	assert.Equal(suite.T(), query.SQL(), "COMMENT ON TABLE user IS 'registered users';")
+
+	query = b.CommentOnColumn("user", "email", "user's email").Query()
+	assert.Equal(suite.T(), query.SQL(), "COMMENT ON COLUMN user.email IS 'user''s email';")
+}
+
+func (suite *BuilderTestSuite) TestBuilderColumnDefault() {
+	query := suite.builder.AlterColumnDefault("user", "active", "TRUE").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nALTER COLUMN active SET DEFAULT TRUE;")
+
+	query = suite.builder.DropColumnDefault("user", "active").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nALTER COLUMN active DROP DEFAULT;")
+
+	b := NewBuilder("sqlite3")
+	query = b.AlterColumnDefault("user", "active", "1").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderAlterColumnNotNull() {
+	query := suite.builder.AlterColumnNotNull("user", "email", true, "VARCHAR(255)").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nMODIFY COLUMN email VARCHAR(255) NOT NULL;")
+
+	query = suite.builder.AlterColumnNotNull("user", "email", false).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.AlterColumnNotNull("user", "email", true).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nALTER COLUMN email SET NOT NULL;")
+
+	query = b.AlterColumnNotNull("user", "email", false).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nALTER COLUMN email DROP NOT NULL;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderAlterTableRenameIndex() {
+	query := suite.builder.AlterTableRenameIndex("user", "index_email", "index_user_email").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nRENAME INDEX index_email TO index_user_email;")
+
+	b := NewBuilder("postgres")
+	query = b.AlterTableRenameIndex("user", "index_email", "index_user_email").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER INDEX index_email RENAME TO index_user_email;")
+
+	b = NewBuilder("sqlite3")
+	query = b.AlterTableRenameIndex("user", "index_email", "index_user_email").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderMaterializedView() {
+	inner := NewBuilder("postgres")
+	inner.Select("category", inner.Sum("price")).From("products").GroupBy("category")
+
+	b := NewBuilder("postgres")
+	query := b.CreateMaterializedView("category_totals", inner).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE MATERIALIZED VIEW category_totals AS\nSELECT category, SUM(price)\nFROM products\nGROUP BY category;")
+
+	query = b.RefreshMaterializedView("category_totals", true).Query()
+	assert.Equal(suite.T(), query.SQL(), "REFRESH MATERIALIZED VIEW CONCURRENTLY category_totals;")
+
+	query = b.RefreshMaterializedView("category_totals", false).Query()
+	assert.Equal(suite.T(), query.SQL(), "REFRESH MATERIALIZED VIEW category_totals;")
+
+	inner.Select("id").From("products").Where(inner.Eq("category", "books"))
+	query = b.CreateMaterializedView("books", inner).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	query = suite.builder.RefreshMaterializedView("category_totals", false).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateFunction() {
+	query := suite.builder.CreateFunction("add", FunctionDef{Returns: "integer"}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateFunction("add", FunctionDef{
+		Parameters: []FuncParam{{"a", "integer"}, {"b", "integer"}},
+		Returns:    "integer",
+		Language:   "sql",
+		Body:       " SELECT a + b ",
+		Volatility: "IMMUTABLE",
+	}).Query()
+
+	assert.Equal(suite.T(), query.SQL(), "CREATE OR REPLACE FUNCTION add(a integer, b integer)\nRETURNS integer\nLANGUAGE sql\nIMMUTABLE\nAS $$ SELECT a + b $$;")
+
+	query = b.CreateFunction("touch", FunctionDef{
+		Returns: "trigger",
+		Body:    " BEGIN NEW.updated_at = NOW(); RETURN NEW; END; ",
+	}).Query()
+
+	assert.Equal(suite.T(), query.SQL(), "CREATE OR REPLACE FUNCTION touch()\nRETURNS trigger\nLANGUAGE plpgsql\nAS $$ BEGIN NEW.updated_at = NOW(); RETURN NEW; END; $$;")
+
+	query = b.CreateFunction("greet", FunctionDef{
+		Returns:  "text",
+		Language: "sql",
+		Body:     " SELECT $$hello$$ ",
+	}).Query()
+
+	assert.Equal(suite.T(), query.SQL(), "CREATE OR REPLACE FUNCTION greet()\nRETURNS text\nLANGUAGE sql\nAS $body$ SELECT $$hello$$ $body$;")
+
+	query = b.CreateFunction("greet", FunctionDef{
+		Returns:  "text",
+		Language: "sql",
+		Body:     " SELECT $$hello$$, $body$world$body$ ",
+	}).Query()
+
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateTrigger() {
+	query := suite.builder.CreateTrigger("user_updated", "BEFORE", "UPDATE", "user", "touch", TriggerOptions{}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateTrigger("user_updated", "BEFORE", "UPDATE", "user", "touch", TriggerOptions{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TRIGGER user_updated BEFORE UPDATE ON user\nFOR EACH ROW\nEXECUTE FUNCTION touch();")
+
+	b.Adapter().SetVersion("10.4")
+	query = b.CreateTrigger("user_audit", "AFTER", "INSERT OR DELETE", "user", "audit", TriggerOptions{
+		ForEachStatement: true,
+		WhenCondition:    "pg_trigger_depth() = 0",
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TRIGGER user_audit AFTER INSERT OR DELETE ON user\nFOR EACH STATEMENT\nWHEN (pg_trigger_depth() = 0)\nEXECUTE PROCEDURE audit();")
+}
+
+func (suite *BuilderTestSuite) TestBuilderDropFunctionTrigger() {
+	query := suite.builder.DropFunction("add", "integer", "integer").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.DropFunction("add", "integer", "integer").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP FUNCTION add(integer, integer);")
+
+	query = b.DropFunctionIfExists("touch").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP FUNCTION IF EXISTS touch();")
+
+	query = b.DropTrigger("user_updated", "user").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP TRIGGER user_updated ON user;")
+
+	query = b.DropTriggerIfExists("user_updated", "user").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP TRIGGER IF EXISTS user_updated ON user;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderListenNotify() {
+	query := suite.builder.Listen("events").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+
+	query = b.Listen("events").Query()
+	assert.Equal(suite.T(), query.SQL(), "LISTEN \"events\";")
+
+	query = b.Notify("events").Query()
+	assert.Equal(suite.T(), query.SQL(), "NOTIFY \"events\";")
+
+	query = b.Notify("events", `{"id": 5}`).Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT pg_notify('events', $1);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{`{"id": 5}`})
+
+	query = b.Listen("OrderEvents").Query()
+	assert.Equal(suite.T(), query.SQL(), "LISTEN \"OrderEvents\";")
+
+	query = b.Notify("OrderEvents").Query()
+	assert.Equal(suite.T(), query.SQL(), "NOTIFY \"OrderEvents\";")
+
+	query = b.Notify("OrderEvents", "created").Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT pg_notify('OrderEvents', $1);")
+
+	query = b.Notify("events", "created", "updated").Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderLockTable() {
+	query := suite.builder.LockTable([]string{"user"}, AccessExclusive).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	b.SetEscaping(true)
+	query = b.LockTable([]string{"user", "public.email"}, ShareRowExclusive).Query()
+	assert.Equal(suite.T(), query.SQL(), "LOCK TABLE \"user\", \"public\".\"email\" IN SHARE ROW EXCLUSIVE MODE;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSavepoint() {
+	query := suite.builder.Savepoint("before_migration").Query()
+	assert.Equal(suite.T(), query.SQL(), "SAVEPOINT before_migration;")
+
+	query = suite.builder.RollbackToSavepoint("before_migration").Query()
+	assert.Equal(suite.T(), query.SQL(), "ROLLBACK TO SAVEPOINT before_migration;")
+
+	query = suite.builder.ReleaseSavepoint("before_migration").Query()
+	assert.Equal(suite.T(), query.SQL(), "RELEASE SAVEPOINT before_migration;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSetConstraints() {
+	query := suite.builder.SetConstraintsDeferred().Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.SetConstraintsDeferred().Query()
+	assert.Equal(suite.T(), query.SQL(), "SET CONSTRAINTS ALL DEFERRED;")
+
+	query = b.SetConstraintsImmediate("fk_user_email", "fk_email_user").Query()
+	assert.Equal(suite.T(), query.SQL(), "SET CONSTRAINTS fk_user_email, fk_email_user IMMEDIATE;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderDo() {
+	query := suite.builder.Do("BEGIN END").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.Do(" BEGIN RAISE NOTICE 'done'; END ").Query()
+	assert.Equal(suite.T(), query.SQL(), "DO LANGUAGE plpgsql $$ BEGIN RAISE NOTICE 'done'; END $$;")
+
+	query = b.Do(" BEGIN PERFORM $$x$$; END ", "plpgsql").Query()
+	assert.Equal(suite.T(), query.SQL(), "DO LANGUAGE plpgsql $body$ BEGIN PERFORM $$x$$; END $body$;")
+
+	query = b.Do("SELECT $$a$$, $body$b$body$").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+	assert.Equal(suite.T(), query.SQL(), "")
+}
+
+func (suite *BuilderTestSuite) TestBuilderPrepareDDL() {
+	query := suite.builder.ExecutePrepared("find_user", 5).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	stmt := NewBuilder("postgres").
+		Select("id", "email").
+		From("user").
+		Where("id = $1 AND email = $2")
+
+	b := NewBuilder("postgres")
+	query = b.PrepareDDL("find_user", []string{"int", "text"}, stmt).Query()
+	assert.Equal(suite.T(), query.SQL(), "PREPARE find_user (int, text) AS SELECT id, email\nFROM user\nWHERE id = $1 AND email = $2;")
+
+	query = b.ExecutePrepared("find_user", 5, "a@b.com").Query()
+	assert.Equal(suite.T(), query.SQL(), "EXECUTE find_user($1,$2);")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{5, "a@b.com"})
+
+	sample := NewBuilder("postgres")
+	sample.Adapter().SetVersion("9.4")
+	sample.Select("id").From("user").Tablesample("SYSTEM", 10).Where("id > $1")
+
+	query = b.PrepareDDL("sample_users", []string{"int"}, sample).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+	assert.Equal(suite.T(), query.Errors()[0].Error(), "TABLESAMPLE is not supported by postgres driver")
+}
+
+func (suite *BuilderTestSuite) TestBuilderLoadDataInfile() {
+	query := suite.builder.LoadDataInfile("/tmp/users.csv", "user", LoadDataOptions{
+		FieldsTerminatedBy: ",",
+		EnclosedBy:         "\"",
+		LinesTerminatedBy:  "\n",
+		IgnoreLines:        1,
+		ColumnMapping:      []string{"id", "email"},
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "LOAD DATA INFILE '/tmp/users.csv'\nINTO TABLE user\nFIELDS TERMINATED BY ',' ENCLOSED BY '\"'\nLINES TERMINATED BY '\n'\nIGNORE 1 LINES\n(id, email);")
+
+	query = suite.builder.LoadDataInfile("/tmp/users.csv", "user", LoadDataOptions{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "LOAD DATA INFILE '/tmp/users.csv'\nINTO TABLE user;")
+
+	b := NewBuilder("postgres")
+	query = b.LoadDataInfile("/tmp/users.csv", "user", LoadDataOptions{}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCluster() {
+	query := suite.builder.Cluster("user", "i_user_email").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.Cluster("user", "i_user_email").Query()
+	assert.Equal(suite.T(), query.SQL(), "CLUSTER user USING i_user_email;")
+
+	query = b.ClusterAll().Query()
+	assert.Equal(suite.T(), query.SQL(), "CLUSTER;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCheckpoint() {
+	query := suite.builder.Checkpoint().Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.Checkpoint().Query()
+	assert.Equal(suite.T(), query.SQL(), "CHECKPOINT;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSetRole() {
+	query := suite.builder.SetRole("tenant").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.SetRole("tenant").Query()
+	assert.Equal(suite.T(), query.SQL(), "SET ROLE tenant;")
+
+	query = b.ResetRole().Query()
+	assert.Equal(suite.T(), query.SQL(), "RESET ROLE;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderRowLevelSecurity() {
+	query := suite.builder.EnableRLS("account").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.EnableRLS("account").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE account\nENABLE ROW LEVEL SECURITY;")
+
+	query = b.DisableRLS("account").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE account\nDISABLE ROW LEVEL SECURITY;")
+
+	query = b.ForceRLS("account").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE account\nFORCE ROW LEVEL SECURITY;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreatePolicy() {
+	query := suite.builder.CreatePolicy("tenant_isolation", "account", PolicyOptions{}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreatePolicy("tenant_isolation", "account", PolicyOptions{
+		Command:   "UPDATE",
+		Roles:     []string{"tenant", "admin"},
+		UsingExpr: "tenant_id = current_setting('app.tenant_id')::int",
+		CheckExpr: "tenant_id = current_setting('app.tenant_id')::int",
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE POLICY tenant_isolation ON account\nFOR UPDATE\nTO tenant, admin\nUSING (tenant_id = current_setting('app.tenant_id')::int)\nWITH CHECK (tenant_id = current_setting('app.tenant_id')::int);")
+
+	query = b.CreatePolicy("read_all", "account", PolicyOptions{UsingExpr: "true"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE POLICY read_all ON account\nUSING (true);")
+
+	b.SetEscaping(true)
+	query = b.CreatePolicy("active_only", "account", PolicyOptions{
+		As:        "RESTRICTIVE",
+		Roles:     []string{"public", "CURRENT_USER", "auditor"},
+		UsingExpr: "active",
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE POLICY \"active_only\" ON \"account\"\nAS RESTRICTIVE\nTO PUBLIC, CURRENT_USER, \"auditor\"\nUSING (active);")
+
+	b.Adapter().SetVersion("9.6")
+	query = b.CreatePolicy("active_only", "account", PolicyOptions{As: "RESTRICTIVE", UsingExpr: "active"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	query = b.CreatePolicy("read_all", "account", PolicyOptions{UsingExpr: "true"}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderInheritTable() {
+	query := suite.builder.InheritTable("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.InheritTable("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE measurement_2024\nINHERIT measurement;")
+
+	query = b.NoInheritTable("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE measurement_2024\nNO INHERIT measurement;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderAttachPartition() {
+	query := suite.builder.DetachPartition("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.AttachPartition("measurement", "measurement_2024", PartitionBound{
+		From: []string{"'2024-01-01'"},
+		To:   []string{"'2025-01-01'"},
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE measurement\nATTACH PARTITION measurement_2024 FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');")
+
+	query = b.AttachPartition("city", "city_eu", PartitionBound{In: []string{"'de'", "'fr'"}}).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE city\nATTACH PARTITION city_eu FOR VALUES IN ('de', 'fr');")
+
+	query = b.AttachPartition("user", "user_0", PartitionBound{Modulus: 4, Remainder: 0}).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE user\nATTACH PARTITION user_0 FOR VALUES WITH (MODULUS 4, REMAINDER 0);")
+
+	query = b.DetachPartition("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE measurement\nDETACH PARTITION measurement_2024;")
+
+	b.Adapter().SetVersion("9.6")
+	query = b.DetachPartition("measurement", "measurement_2024").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreatePartitionedTable() {
+	query := suite.builder.CreatePartitionedTable("measurement", PartitionStrategy{}, []string{"id INT"}, []string{}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreatePartitionedTable(
+		"measurement",
+		PartitionStrategy{Method: "range", Columns: []string{"logdate"}},
+		[]string{
+			"city_id INT NOT NULL",
+			"logdate DATE NOT NULL",
+		},
+		[]string{},
+	).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TABLE measurement(\n\tcity_id INT NOT NULL,\n\tlogdate DATE NOT NULL\n) PARTITION BY RANGE (logdate);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateTablePartition() {
+	query := suite.builder.CreateTablePartition("user_0", "user", PartitionBound{Modulus: 2}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateTablePartition("measurement_2024", "measurement", PartitionBound{
+		From: []string{"'2024-01-01'"},
+		To:   []string{"'2025-01-01'"},
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TABLE measurement_2024 PARTITION OF measurement FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');")
+
+	query = b.CreateTablePartition("city_eu", "city", PartitionBound{In: []string{"'de'", "'fr'"}}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TABLE city_eu PARTITION OF city FOR VALUES IN ('de', 'fr');")
+
+	query = b.CreateTablePartition("user_1", "user", PartitionBound{Modulus: 2, Remainder: 1}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TABLE user_1 PARTITION OF user FOR VALUES WITH (MODULUS 2, REMAINDER 1);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderAlterTableSetUnlogged() {
+	query := suite.builder.AlterTableSetUnlogged("staging").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.AlterTableSetUnlogged("staging").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE staging\nSET UNLOGGED;")
+
+	query = b.AlterTableSetLogged("staging").Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER TABLE staging\nSET LOGGED;")
+}
+
+func (suite *BuilderTestSuite) TestBuilderExtension() {
+	query := suite.builder.CreateExtension("pg_trgm").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateExtension("pg_trgm").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE EXTENSION \"pg_trgm\";")
+
+	query = b.CreateExtensionIfNotExists("uuid-ossp").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";")
+
+	query = b.DropExtension("postgis").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP EXTENSION \"postgis\";")
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateCollation() {
+	query := suite.builder.CreateCollation("german", CollationOptions{Locale: "de_DE"}).Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateCollation("german", CollationOptions{Locale: "de_DE"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE COLLATION german (LOCALE = 'de_DE');")
+
+	deterministic := false
+	query = b.CreateCollation("case_insensitive", CollationOptions{
+		Provider:      "icu",
+		Locale:        "und-u-ks-level2",
+		Deterministic: &deterministic,
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE COLLATION case_insensitive (PROVIDER = icu, LOCALE = 'und-u-ks-level2', DETERMINISTIC = false);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderPublication() {
+	query := suite.builder.CreatePublication("pub", "user").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreatePublication("pub", "user", "email").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE PUBLICATION pub FOR TABLE user, email;")
+
+	query = b.CreatePublication("pub").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE PUBLICATION pub;")
+
+	query = b.AlterPublication("pub", []string{"session"}, []string{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER PUBLICATION pub ADD TABLE session;")
+
+	query = b.AlterPublication("pub", []string{}, []string{"email"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "ALTER PUBLICATION pub DROP TABLE email;")
+
+	query = b.AlterPublication("pub", []string{"session"}, []string{"email"}).Query()
+	assert.Equal(suite.T(), query.SQL(), "")
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	query = b.DropPublication("pub").Query()
+	assert.Equal(suite.T(), query.SQL(), "DROP PUBLICATION pub;")
+
+	b.Adapter().SetVersion("9.6")
+	query = b.DropPublication("pub").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderCreateSubscription() {
+	query := suite.builder.CreateSubscription("sub", "host=primary dbname=app", "pub").Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	query = b.CreateSubscription("sub", "host=primary dbname=app", "pub", "audit_pub").Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE SUBSCRIPTION sub\nCONNECTION 'host=primary dbname=app'\nPUBLICATION pub, audit_pub;")
+	assert.False(suite.T(), query.Transactional())
+
+	createSlot := false
+	query = b.CreateSubscriptionWithOptions("sub", "host=primary dbname=app", []string{"pub"}, SubscriptionOptions{
+		SlotName:          "sub_slot",
+		SynchronousCommit: "off",
+		CreateSlot:        &createSlot,
+	}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE SUBSCRIPTION sub\nCONNECTION 'host=primary dbname=app'\nPUBLICATION pub\nWITH (slot_name = 'sub_slot', synchronous_commit = 'off', create_slot = false);")
+	assert.True(suite.T(), query.Transactional())
+}
+
+func (suite *BuilderTestSuite) TestBuilderDebugString() {
+	suite.builder.
+		Select("id", "email").
+		From("user").
+		Where(suite.builder.Eq("id", 5))
+
+	assert.Equal(suite.T(), suite.builder.DebugString(), "[0] SELECT id, email\n[1] FROM user\n[2] WHERE id = ?\nbindings: [5]")
+
+	query := suite.builder.Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id, email\nFROM user\nWHERE id = ?;")
+	assert.Equal(suite.T(), suite.builder.DebugString(), "bindings: []")
+}
+
+func (suite *BuilderTestSuite) TestBuilderClauseCount() {
+	assert.Equal(suite.T(), suite.builder.ClauseCount(), 0)
+	assert.False(suite.T(), suite.builder.HasClause("WHERE"))
+
+	suite.builder.
+		Select("id").
+		From("user").
+		Where(suite.builder.Eq("id", 5))
+
+	assert.Equal(suite.T(), suite.builder.ClauseCount(), 3)
+	assert.True(suite.T(), suite.builder.HasClause("WHERE"))
+	assert.False(suite.T(), suite.builder.HasClause("ORDER BY"))
+
+	suite.builder.Query()
+	assert.Equal(suite.T(), suite.builder.ClauseCount(), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderBindingCount() {
+	assert.Equal(suite.T(), suite.builder.BindingCount(), 0)
+
+	suite.builder.
+		Select("id").
+		From("user").
+		Where(suite.builder.And(
+			suite.builder.Eq("id", 5),
+			suite.builder.In("email", "a@b.com", "c@d.com"),
+		))
+
+	assert.Equal(suite.T(), suite.builder.BindingCount(), 3)
+
+	suite.builder.Query()
+	assert.Equal(suite.T(), suite.builder.BindingCount(), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderInlineValues() {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	values := suite.builder.InlineValues("it's", 5, 2.5, float32(0.1), true, false, nil, created)
+	assert.Equal(suite.T(), values, "'it''s', 5, 2.5, 0.1, TRUE, FALSE, NULL, '2024-01-02 03:04:05'")
+	assert.Equal(suite.T(), suite.builder.BindingCount(), 0)
+
+	local := time.Date(2024, 1, 2, 5, 4, 5, 500000000, time.FixedZone("EET", 2*60*60))
+	assert.Equal(suite.T(), suite.builder.InlineValues(local), "'2024-01-02 03:04:05.5'")
+
+	assert.Equal(suite.T(), suite.builder.InlineValues(math.NaN()), "NULL")
+	query := suite.builder.Query()
+	assert.Equal(suite.T(), len(query.Errors()), 1)
+
+	b := NewBuilder("postgres")
+	assert.Equal(suite.T(), b.InlineValues("it's", []byte("raw")), "'it''s', 'raw'")
+	assert.Equal(suite.T(), b.InlineValues(created, local), "'2024-01-02T03:04:05Z', '2024-01-02T05:04:05.5+02:00'")
+	assert.Equal(suite.T(), b.InlineValues(math.NaN(), math.Inf(1), math.Inf(-1)), "'NaN', 'Infinity', '-Infinity'")
+	assert.Equal(suite.T(), len(b.Query().Errors()), 0)
+}
+
+func (suite *BuilderTestSuite) TestBuilderDefaultValue() {
+	assert.Equal(suite.T(), suite.builder.DefaultValue("VARCHAR(255)"), "''")
+	assert.Equal(suite.T(), suite.builder.DefaultValue("bigint"), "0")
+	assert.Equal(suite.T(), suite.builder.DefaultValue("BOOLEAN"), "FALSE")
+	assert.Equal(suite.T(), suite.builder.DefaultValue("DATETIME"), "CURRENT_TIMESTAMP")
+	assert.Equal(suite.T(), suite.builder.DefaultValue("BLOB"), "NULL")
+
+	pg := NewBuilder("postgres")
+	assert.Equal(suite.T(), pg.DefaultValue("timestamptz"), "NOW()")
+
+	sqlite := NewBuilder("sqlite3")
+	assert.Equal(suite.T(), sqlite.DefaultValue("BOOLEAN"), "0")
+	assert.Equal(suite.T(), sqlite.DefaultValue("TIMESTAMP"), "CURRENT_TIMESTAMP")
+}
+
+func (suite *BuilderTestSuite) TestBuilderGenUUID() {
+	assert.Equal(suite.T(), suite.builder.GenUUID(), "UUID()")
+
+	pg := NewBuilder("postgres")
+	assert.Equal(suite.T(), pg.GenUUID(), "gen_random_uuid()")
+
+	pg.Adapter().SetVersion("12.4")
+	assert.Equal(suite.T(), pg.GenUUID(), "uuid_generate_v4()")
+
+	sqlite := NewBuilder("sqlite3")
+	assert.Equal(suite.T(), sqlite.GenUUID(), "")
+	assert.Equal(suite.T(), len(sqlite.Query().Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderAutoIncrementPK() {
+	assert.Equal(suite.T(), suite.builder.AutoIncrementPK("id"), "id INT AUTO_INCREMENT PRIMARY KEY")
+	assert.Equal(suite.T(), NewBuilder("postgres").AutoIncrementPK("id"), "id SERIAL PRIMARY KEY")
+	assert.Equal(suite.T(), NewBuilder("sqlite3").AutoIncrementPK("id"), "id INTEGER PRIMARY KEY AUTOINCREMENT")
+
+	query := suite.builder.CreateTable("user", []string{
+		suite.builder.AutoIncrementPK("id"),
+		"email VARCHAR(255) NOT NULL",
+	}, []string{}).Query()
+	assert.Equal(suite.T(), query.SQL(), "CREATE TABLE user(\n\tid INT AUTO_INCREMENT PRIMARY KEY,\n\temail VARCHAR(255) NOT NULL\n);")
+}
+
+func (suite *BuilderTestSuite) TestBuilderTimestampColumns() {
+	assert.Equal(suite.T(), suite.builder.CreatedAtColumn("created_at"), "created_at DATETIME DEFAULT CURRENT_TIMESTAMP")
+	assert.Equal(suite.T(), suite.builder.UpdatedAtColumn("updated_at"), "updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP")
+
+	pg := NewBuilder("postgres")
+	assert.Equal(suite.T(), pg.CreatedAtColumn("created_at"), "created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()")
+	assert.Equal(suite.T(), pg.UpdatedAtColumn("updated_at"), "updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()")
+
+	sqlite := NewBuilder("sqlite3")
+	assert.Equal(suite.T(), sqlite.CreatedAtColumn("created_at"), "created_at DATETIME DEFAULT CURRENT_TIMESTAMP")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSoftDeleteColumn() {
+	assert.Equal(suite.T(), suite.builder.SoftDeleteColumn("deleted_at"), "deleted_at DATETIME NULL")
+	assert.Equal(suite.T(), NewBuilder("postgres").SoftDeleteColumn("deleted_at"), "deleted_at TIMESTAMP WITH TIME ZONE NULL")
+}
+
+func (suite *BuilderTestSuite) TestBuilderUUIDPrimaryKey() {
+	assert.Equal(suite.T(), suite.builder.UUIDPrimaryKey("id"), "id CHAR(36) PRIMARY KEY")
+	assert.Equal(suite.T(), NewBuilder("sqlite3").UUIDPrimaryKey("id"), "id TEXT PRIMARY KEY")
+
+	pg := NewBuilder("postgres")
+	assert.Equal(suite.T(), pg.UUIDPrimaryKey("id"), "id UUID PRIMARY KEY DEFAULT gen_random_uuid()")
+
+	pg.Adapter().SetVersion("12")
+	assert.Equal(suite.T(), pg.UUIDPrimaryKey("id"), "id UUID PRIMARY KEY DEFAULT uuid_generate_v4()")
+}
+
+func (suite *BuilderTestSuite) TestBuilderJsonColumn() {
+	assert.Equal(suite.T(), suite.builder.JsonColumn("settings"), "settings JSON")
+	assert.Equal(suite.T(), NewBuilder("sqlite3").JsonColumn("settings"), "settings TEXT")
+	assert.Equal(suite.T(), NewBuilder("postgres").JsonColumn("settings"), "settings JSONB")
+
+	assert.Equal(suite.T(), NewBuilder("postgres").JsonbColumn("settings"), "settings JSONB")
+	assert.Equal(suite.T(), suite.builder.JsonbColumn("settings"), "")
+	assert.Equal(suite.T(), len(suite.builder.Query().Errors()), 1)
+}
+
+func (suite *BuilderTestSuite) TestBuilderArrayColumn() {
+	assert.Equal(suite.T(), suite.builder.TextArrayColumn("tags"), "")
+	assert.Equal(suite.T(), len(suite.builder.Query().Errors()), 1)
+
+	b := NewBuilder("postgres")
+	assert.Equal(suite.T(), b.TextArrayColumn("tags"), "tags TEXT[] NOT NULL DEFAULT '{}'::text[]")
+	assert.Equal(suite.T(), b.IntArrayColumn("scores"), "scores INTEGER[] NOT NULL DEFAULT '{}'::integer[]")
+	assert.Equal(suite.T(), b.UUIDArrayColumn("friend_ids"), "friend_ids UUID[] NOT NULL DEFAULT '{}'::uuid[]")
+	assert.Equal(suite.T(), b.ArrayColumn("ranges", "numeric"), "ranges NUMERIC[] NOT NULL DEFAULT '{}'::numeric[]")
+}
+
+func (suite *BuilderTestSuite) TestBuilderSpatialColumns() {
+	assert.Equal(suite.T(), suite.builder.PointColumn("location"), "")
+	assert.Equal(suite.T(), len(suite.builder.Query().Errors()), 1)
+
+	b := NewBuilder("postgres")
+	assert.Equal(suite.T(), b.PointColumn("location"), "location GEOMETRY(POINT, 4326)")
+	assert.Equal(suite.T(), b.GeographyColumn("area", "polygon", 4326), "area GEOGRAPHY(POLYGON, 4326)")
+}
+
+func (suite *BuilderTestSuite) TestBuilderVector() {
+	assert.Equal(suite.T(), suite.builder.VectorColumn("embedding", 3), "")
+	assert.Equal(suite.T(), len(suite.builder.Query().Errors()), 1)
+
+	b := NewBuilder("postgres")
+	assert.Equal(suite.T(), b.VectorColumn("embedding", 1536), "embedding vector(1536)")
+
+	query := b.
+		Select("id").
+		From("document").
+		OrderBy(b.CosineDistance("embedding", []float32{0.1, 0.25, 1})).
+		Limit(0, 5).
+		Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM document\nORDER BY embedding <=> $1::vector\nLIMIT 5 OFFSET 0;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"[0.1,0.25,1]"})
+
+	query = b.
+		Select("id").
+		From("document").
+		Where(fmt.Sprintf("%s < 0.5", b.L2Distance("embedding", "[1,2,3]"))).
+		Query()
+	assert.Equal(suite.T(), query.SQL(), "SELECT id\nFROM document\nWHERE embedding <-> $1::vector < 0.5;")
+	assert.Equal(suite.T(), query.Bindings(), []interface{}{"[1,2,3]"})
+}
+
+func TestBuilderSuite(t *testing.T) {
+	suite.Run(t, new(BuilderTestSuite))
+}
+
+func TestBuilderWithOptions(t *testing.T) {
+	b, err := NewBuilderWithOptions(BuilderOptions{
+		Driver:      "postgres",
+		Escaping:    true,
+		LogFlags:    LQuery,
+		TablePrefix: "app_",
+		MaxBindings: 2,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, b.Adapter().Driver(), "postgres")
+	assert.Equal(t, b.Escaping(), true)
+	assert.Equal(t, b.LogFlags(), LQuery)
+	assert.Equal(t, b.TablePrefix(), "app_")
+
+	query := b.Select("id").From("user u").InnerJoin("email e", "u.id = e.user_id").Where(b.Eq("u.id", 5)).Query()
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM \"app_user\" u\nINNER JOIN \"app_email\" e ON u.id = e.user_id\nWHERE \"u\".\"id\" = $1;")
+	assert.Equal(t, len(query.Errors()), 0)
+
+	query = b.Select("id").From("user").Where(b.In("id", 1, 2, 3)).Query()
+	assert.Equal(t, len(query.Errors()), 1)
+
+	query = b.CreateIndex("index_user_email", "user", "email").Query()
+	assert.Equal(t, query.SQL(), "CREATE INDEX index_user_email ON \"app_user\"(\"email\");")
+
+	query = b.CreateIndexConcurrently("index_user_email", "public.user", "email").Query()
+	assert.Equal(t, query.SQL(), "CREATE INDEX CONCURRENTLY index_user_email ON \"public\".\"app_user\"(\"email\");")
+
+	query = b.Reindex("user").Query()
+	assert.Equal(t, query.SQL(), "REINDEX TABLE \"app_user\";")
+
+	query = b.Cluster("user", "index_user_email").Query()
+	assert.Equal(t, query.SQL(), "CLUSTER \"app_user\" USING \"index_user_email\";")
+
+	query = b.AlterTable("user").Add("name", "TEXT").Query()
+	assert.Equal(t, query.SQL(), "ALTER TABLE \"app_user\"\nADD name TEXT;")
+
+	query = b.AlterColumnNotNull("user", "email", true).Query()
+	assert.Equal(t, query.SQL(), "ALTER TABLE \"app_user\"\nALTER COLUMN \"email\" SET NOT NULL;")
+
+	_, err = NewBuilderWithOptions(BuilderOptions{Driver: "oracle"})
+	assert.NotNil(t, err)
+
+	_, err = NewBuilderWithOptions(BuilderOptions{Driver: "mysql", MaxBindings: -1})
+	assert.NotNil(t, err)
+}
+
+func TestBuilderTracer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	b, _ := NewBuilderWithOptions(BuilderOptions{
+		Driver:   "mysql",
+		LogFlags: LTiming,
+		Logger:   log.New(buf, "", 0),
+	})
+
+	done := b.Tracer("select user")
+	b.Select("id").From("user").Query()
+	done()
+
+	assert.True(t, strings.HasPrefix(buf.String(), "select user took "))
+
+	buf.Reset()
+	b.SetLogFlags(LQuery)
+	b.Tracer("select user")()
+	assert.Equal(t, buf.String(), "")
+}
+
+func TestBuilderMaxQueryLength(t *testing.T) {
+	b := NewBuilder("mysql").SetMaxQueryLength(20)
+
+	query := b.Select("id").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 0)
+
+	query = b.Select("id", "email", "full_name").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 1)
+
+	// length is measured in bytes
+	query = b.Select("'ğğğğğğğ'").Query()
+	assert.Equal(t, len(query.Errors()), 1)
+
+	b.SetMaxQueryLength(0)
+	query = b.Select("id", "email", "full_name").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 0)
+}
+
+func TestBuilderQueryTimeout(t *testing.T) {
+	b := NewBuilder("mysql").SetQueryTimeout(5 * time.Second)
+	query := b.Select("id").From("user").Query()
+	assert.Equal(t, query.SQL(), "SELECT /*+ MAX_EXECUTION_TIME(5000) */ id\nFROM user;")
+
+	query = b.Delete("user").Query()
+	assert.Equal(t, query.SQL(), "DELETE FROM user;")
+
+	b.Select("id").From("user")
+	b.SetQueryTimeout(time.Second, TimeoutSetLocal)
+	query = b.Query()
+	assert.Equal(t, len(query.Errors()), 1)
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM user;")
+	assert.Equal(t, b.TimeoutQuery().SQL(), "")
+
+	query = b.Select("id").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 0)
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM user;")
+
+	pg := NewBuilder("postgres").SetQueryTimeout(1500 * time.Millisecond)
+	query = pg.Select("id").From("user").Where(pg.Eq("id", 5)).Query()
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM user\nWHERE id = $1;")
+	assert.Equal(t, query.Bindings(), []interface{}{5})
+	assert.Equal(t, pg.TimeoutQuery().SQL(), "SET LOCAL statement_timeout = 1500;")
+	assert.Equal(t, len(pg.TimeoutQuery().Bindings()), 0)
+
+	pg.SetQueryTimeout(1500*time.Millisecond, TimeoutHint)
+	query = pg.Select("id").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 1)
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM user;")
+	assert.Equal(t, pg.TimeoutQuery().SQL(), "")
+
+	pg.SetQueryTimeout(0)
+	query = pg.Select("id").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 0)
+	assert.Equal(t, pg.TimeoutQuery().SQL(), "")
+
+	sqlite := NewBuilder("sqlite3").SetQueryTimeout(time.Second)
+	query = sqlite.Select("id").From("user").Query()
+	assert.Equal(t, len(query.Errors()), 1)
+	assert.Equal(t, query.SQL(), "SELECT id\nFROM user;")
+}
+
+func TestBuilderQueryHook(t *testing.T) {
+	b := NewBuilder("mysql")
+	queries := []*Query{}
+	b.SetQueryHook(func(query *Query) {
+		queries = append(queries, query)
+	})
+
+	b.Select("id").From("user").Query()
+	assert.Equal(t, len(queries), 1)
+	assert.Equal(t, queries[0].SQL(), "SELECT id\nFROM user;")
+
+	b.SetQueryHook(nil)
+	b.Select("id").From("user").Query()
+	assert.Equal(t, len(queries), 1)
 }
@@ -1,20 +1,34 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // DefaultAdapter is a type of adapter that can be used with unsupported sql drivers
 type DefaultAdapter struct {
 	escaping bool
+	version  string
 }
 
 // Escape wraps the string with escape characters of the adapter
 func (a *DefaultAdapter) Escape(str string) string {
 	if a.escaping {
-		return fmt.Sprintf("`%s`", str)
+		return a.QuoteIdentifier(str)
 	}
 	return str
 }
 
+// QuoteIdentifier wraps the identifier with quote characters of the adapter regardless of escaping
+func (a *DefaultAdapter) QuoteIdentifier(str string) string {
+	return fmt.Sprintf("`%s`", strings.Replace(str, "`", "``", -1))
+}
+
+// QuoteLiteral wraps the string with single quotes escaping single quotes by doubling them
+func (a *DefaultAdapter) QuoteLiteral(str string) string {
+	return fmt.Sprintf("'%s'", strings.Replace(str, "'", "''", -1))
+}
+
 // EscapeAll wraps all elements of string array
 func (a *DefaultAdapter) EscapeAll(strings []string) []string {
 	return escapeAll(a, strings[0:])
@@ -54,6 +68,21 @@ func (a *DefaultAdapter) SupportsInlinePrimaryKey() bool { return false }
 // SupportsUnsigned returns whether driver supports unsigned type mappings or not
 func (a *DefaultAdapter) SupportsUnsigned() bool { return false }
 
+// SupportsFeature returns whether driver supports the feature or not
+func (a *DefaultAdapter) SupportsFeature(f Feature) bool {
+	return false
+}
+
+// Version returns the database version of adapter
+func (a *DefaultAdapter) Version() string {
+	return a.version
+}
+
+// SetVersion sets the database version of adapter
+func (a *DefaultAdapter) SetVersion(version string) {
+	a.version = version
+}
+
 // Driver returns the current driver of adapter
 func (a *DefaultAdapter) Driver() string {
 	return ""
@@ -36,7 +36,12 @@ type Engine struct {
 }
 
 // Exec executes insert & update type queries and returns sql.Result and error
+// If the query has errors, the first one is returned without executing the query
 func (e *Engine) Exec(query *Query) (sql.Result, error) {
+	if err := queryError(query); err != nil {
+		return nil, err
+	}
+
 	stmt, err := e.db.Prepare(query.SQL())
 	if err != nil {
 		return nil, err
@@ -51,25 +56,49 @@ func (e *Engine) Exec(query *Query) (sql.Result, error) {
 }
 
 // QueryRow wraps *sql.DB.QueryRow()
-func (e *Engine) QueryRow(query *Query) *sql.Row {
-	return e.db.QueryRow(query.SQL(), query.Bindings()...)
+// If the query has errors, the first one is returned without executing the query
+func (e *Engine) QueryRow(query *Query) (*sql.Row, error) {
+	if err := queryError(query); err != nil {
+		return nil, err
+	}
+	return e.db.QueryRow(query.SQL(), query.Bindings()...), nil
 }
 
 // Query wraps *sql.DB.Query()
+// If the query has errors, the first one is returned without executing the query
 func (e *Engine) Query(query *Query) (*sql.Rows, error) {
+	if err := queryError(query); err != nil {
+		return nil, err
+	}
 	return e.db.Query(query.SQL(), query.Bindings()...)
 }
 
 // Get maps the single row to a model
+// If the query has errors, the first one is returned without executing the query
 func (e *Engine) Get(query *Query, model interface{}) error {
+	if err := queryError(query); err != nil {
+		return err
+	}
 	return e.db.Get(model, query.SQL(), query.Bindings()...)
 }
 
 // Select maps multiple rows to a model array
+// If the query has errors, the first one is returned without executing the query
 func (e *Engine) Select(query *Query, model interface{}) error {
+	if err := queryError(query); err != nil {
+		return err
+	}
 	return e.db.Select(model, query.SQL(), query.Bindings()...)
 }
 
+// queryError returns the first error of the query such as an unsupported feature
+func queryError(query *Query) error {
+	if errs := query.Errors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
 // DB returns sql.DB of wrapped engine connection
 func (e *Engine) DB() *sqlx.DB {
 	return e.db
@@ -49,3 +49,30 @@ func TestEngineFail(t *testing.T) {
 	_, err = engine.Exec(query)
 	assert.NotNil(t, err)
 }
+
+func TestEngineQueryErrors(t *testing.T) {
+	engine, err := NewEngine("sqlite3", "./qb_test.db")
+	assert.Nil(t, err)
+
+	query := NewBuilder("mysql").
+		Select("id").
+		From("user").
+		FullOuterJoin("email e", "user.id = e.user_id").
+		Query()
+	assert.Equal(t, len(query.Errors()), 1)
+
+	_, err = engine.Exec(query)
+	assert.Equal(t, err, query.Errors()[0])
+
+	_, err = engine.Query(query)
+	assert.Equal(t, err, query.Errors()[0])
+
+	_, err = engine.QueryRow(query)
+	assert.Equal(t, err, query.Errors()[0])
+
+	var ids []int
+	assert.Equal(t, engine.Select(query, &ids), query.Errors()[0])
+
+	var id int
+	assert.Equal(t, engine.Get(query, &id), query.Errors()[0])
+}
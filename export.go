@@ -0,0 +1,272 @@
+package qb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ExportSchema generates create table statements of the tables in the current schema
+// using information_schema and returns them as a single sql string.
+// Columns, primary keys and unique constraints are exported whereas foreign keys and indices are not
+// NOTE: Not supported by sqlite
+func (b *Builder) ExportSchema(db *sql.DB) (string, error) {
+	driver := b.adapter.Driver()
+	if !b.adapter.SupportsFeature(FeatureInformationSchema) {
+		return "", fmt.Errorf("%s is not supported by %s driver", FeatureInformationSchema, driver)
+	}
+
+	schema := "current_schema()"
+	if driver == "mysql" {
+		schema = "DATABASE()"
+	}
+
+	ib := NewBuilder(driver)
+	tables, err := exportTables(db, ib, schema)
+	if err != nil {
+		return "", err
+	}
+
+	ddl := NewBuilder(driver)
+	ddl.SetEscaping(true)
+
+	statements := []string{}
+	for _, table := range tables {
+		fields, err := exportColumns(db, ib, ddl, schema, table)
+		if err != nil {
+			return "", err
+		}
+
+		constraints, err := exportConstraints(db, ib, ddl, schema, table)
+		if err != nil {
+			return "", err
+		}
+
+		statements = append(statements, ddl.CreateTable(table, fields, constraints).Query().SQL())
+	}
+
+	return strings.Join(statements, "\n\n"), nil
+}
+
+// exportTables returns the base table names of schema ordered by name
+func exportTables(db *sql.DB, ib *Builder, schema string) ([]string, error) {
+	query := ib.
+		Select("table_name").
+		From("information_schema.tables").
+		Where(ib.And(
+			fmt.Sprintf("table_schema = %s", schema),
+			ib.Eq("table_type", "BASE TABLE"),
+		)).
+		OrderBy("table_name").
+		Query()
+
+	rows, err := db.Query(query.SQL(), query.Bindings()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+// serialTypes are the serial types of postgres integer types whose defaults are sequences
+var serialTypes = map[string]string{
+	"int2": "SMALLSERIAL",
+	"int4": "SERIAL",
+	"int8": "BIGSERIAL",
+}
+
+// datetimeTypes are the postgres types that accept a fractional seconds precision
+var datetimeTypes = map[string]bool{
+	"timestamp":   true,
+	"timestamptz": true,
+	"time":        true,
+	"timetz":      true,
+}
+
+// postgresColumn is a row of information_schema.columns of postgres
+type postgresColumn struct {
+	name              string
+	udt               string
+	length            sql.NullInt64
+	precision         sql.NullInt64
+	scale             sql.NullInt64
+	datetimePrecision sql.NullInt64
+	isNullable        string
+	def               sql.NullString
+	isIdentity        sql.NullString
+	generation        sql.NullString
+}
+
+// exportColumns returns the column definitions of table ordered by position
+func exportColumns(db *sql.DB, ib *Builder, ddl *Builder, schema string, table string) ([]string, error) {
+	mysql := ib.adapter.Driver() == "mysql"
+
+	// postgres data_type is "ARRAY" or "USER-DEFINED" for arrays & custom types whereas udt_name is the actual type.
+	// mysql column_type includes the length, precision & scale and extra includes auto_increment
+	columns := []string{"column_name", "column_type", "is_nullable", "column_default", "extra"}
+	if !mysql {
+		columns = []string{"column_name", "udt_name", "character_maximum_length", "numeric_precision", "numeric_scale", "datetime_precision", "is_nullable", "column_default"}
+		if ib.adapter.SupportsFeature(FeatureIdentityColumns) {
+			columns = append(columns, "is_identity", "identity_generation")
+		} else {
+			columns = append(columns, "NULL", "NULL")
+		}
+	}
+
+	query := ib.
+		Select(columns...).
+		From("information_schema.columns").
+		Where(ib.And(
+			fmt.Sprintf("table_schema = %s", schema),
+			ib.Eq("table_name", table),
+		)).
+		OrderBy("ordinal_position").
+		Query()
+
+	rows, err := db.Query(query.SQL(), query.Bindings()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []string{}
+	for rows.Next() {
+		if mysql {
+			var (
+				name       string
+				typ        string
+				isNullable string
+				def        sql.NullString
+				extra      sql.NullString
+			)
+			if err := rows.Scan(&name, &typ, &isNullable, &def, &extra); err != nil {
+				return nil, err
+			}
+			fields = append(fields, exportMysqlColumn(ddl, name, typ, isNullable, def, extra))
+			continue
+		}
+
+		var c postgresColumn
+		if err := rows.Scan(&c.name, &c.udt, &c.length, &c.precision, &c.scale, &c.datetimePrecision, &c.isNullable, &c.def, &c.isIdentity, &c.generation); err != nil {
+			return nil, err
+		}
+		fields = append(fields, exportPostgresColumn(ddl, c))
+	}
+	return fields, rows.Err()
+}
+
+// exportPostgresColumn returns the column definition using udt_name of the column
+// Length, numeric precision & scale and datetime precision are added to the type,
+// sequence defaults are converted to serial types and identity columns are generated as identity
+func exportPostgresColumn(ddl *Builder, c postgresColumn) string {
+	typ := strings.ToUpper(c.udt)
+	switch {
+	case strings.HasPrefix(c.udt, "_"):
+		typ = fmt.Sprintf("%s[]", strings.ToUpper(c.udt[1:]))
+	case c.length.Valid:
+		typ = fmt.Sprintf("%s(%d)", typ, c.length.Int64)
+	case c.udt == "numeric" && c.precision.Valid:
+		typ = fmt.Sprintf("%s(%d,%d)", typ, c.precision.Int64, c.scale.Int64)
+	case datetimeTypes[c.udt] && c.datetimePrecision.Valid:
+		typ = fmt.Sprintf("%s(%d)", typ, c.datetimePrecision.Int64)
+	}
+
+	def := c.def
+	if serial, ok := serialTypes[c.udt]; ok && def.Valid && strings.HasPrefix(def.String, "nextval(") {
+		typ = serial
+		def.Valid = false
+	}
+
+	field := fmt.Sprintf("%s %s", ddl.adapter.Escape(c.name), typ)
+	if c.isIdentity.String == "YES" {
+		field = fmt.Sprintf("%s GENERATED %s AS IDENTITY", field, c.generation.String)
+	}
+	if c.isNullable == "NO" {
+		field += " NOT NULL"
+	}
+	if def.Valid {
+		field = fmt.Sprintf("%s DEFAULT %s", field, def.String)
+	}
+	return field
+}
+
+// exportMysqlColumn returns the column definition using column_type of the column
+// Since mysql reports literal defaults without quotes, defaults other than expressions are quoted
+func exportMysqlColumn(ddl *Builder, name string, typ string, isNullable string, def sql.NullString, extra sql.NullString) string {
+	field := fmt.Sprintf("%s %s", ddl.adapter.Escape(name), strings.ToUpper(typ))
+	if isNullable == "NO" {
+		field += " NOT NULL"
+	}
+	if def.Valid {
+		if strings.Contains(extra.String, "DEFAULT_GENERATED") || strings.HasPrefix(strings.ToUpper(def.String), "CURRENT_TIMESTAMP") {
+			field = fmt.Sprintf("%s DEFAULT %s", field, def.String)
+		} else {
+			field = fmt.Sprintf("%s DEFAULT %s", field, ddl.adapter.QuoteLiteral(def.String))
+		}
+	}
+	if strings.Contains(strings.ToLower(extra.String), "auto_increment") {
+		field += " AUTO_INCREMENT"
+	}
+	return field
+}
+
+// exportConstraints returns the primary key and unique constraints of table
+func exportConstraints(db *sql.DB, ib *Builder, ddl *Builder, schema string, table string) ([]string, error) {
+	query := ib.
+		Select("tc.constraint_name", "tc.constraint_type", "kcu.column_name").
+		From("information_schema.table_constraints tc").
+		InnerJoin(
+			"information_schema.key_column_usage kcu",
+			"kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema AND kcu.table_name = tc.table_name",
+		).
+		Where(ib.And(
+			fmt.Sprintf("tc.table_schema = %s", schema),
+			ib.Eq("tc.table_name", table),
+			"tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')",
+		)).
+		OrderBy("tc.constraint_name", "kcu.ordinal_position").
+		Query()
+
+	rows, err := db.Query(query.SQL(), query.Bindings()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	types := map[string]string{}
+	columns := map[string][]string{}
+	for rows.Next() {
+		var name, typ, column string
+		if err := rows.Scan(&name, &typ, &column); err != nil {
+			return nil, err
+		}
+		if _, ok := types[name]; !ok {
+			names = append(names, name)
+			types[name] = typ
+		}
+		columns[name] = append(columns[name], ddl.adapter.Escape(column))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	constraints := []string{}
+	for _, name := range names {
+		if types[name] == "PRIMARY KEY" {
+			constraints = append(constraints, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(columns[name], ", ")))
+			continue
+		}
+		constraints = append(constraints, fmt.Sprintf("CONSTRAINT %s %s", ddl.adapter.Escape(name), Unique(columns[name]...).Name))
+	}
+	return constraints, nil
+}
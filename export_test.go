@@ -0,0 +1,79 @@
+package qb
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExportSchemaNotSupported(t *testing.T) {
+	b := NewBuilder("sqlite3")
+	schema, err := b.ExportSchema(nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, schema, "")
+}
+
+func TestExportPostgresColumn(t *testing.T) {
+	ddl := NewBuilder("postgres")
+	valid := func(n int64) sql.NullInt64 { return sql.NullInt64{Int64: n, Valid: true} }
+
+	column := exportPostgresColumn(ddl, postgresColumn{name: "tags", udt: "_text", isNullable: "NO"})
+	assert.Equal(t, column, "tags TEXT[] NOT NULL")
+
+	column = exportPostgresColumn(ddl, postgresColumn{name: "location", udt: "geometry", isNullable: "YES"})
+	assert.Equal(t, column, "location GEOMETRY")
+
+	column = exportPostgresColumn(ddl, postgresColumn{
+		name:       "name",
+		udt:        "varchar",
+		length:     valid(50),
+		isNullable: "NO",
+		def:        sql.NullString{String: "'x'::character varying", Valid: true},
+	})
+	assert.Equal(t, column, "name VARCHAR(50) NOT NULL DEFAULT 'x'::character varying")
+
+	column = exportPostgresColumn(ddl, postgresColumn{name: "price", udt: "numeric", precision: valid(10), scale: valid(2), isNullable: "NO"})
+	assert.Equal(t, column, "price NUMERIC(10,2) NOT NULL")
+
+	column = exportPostgresColumn(ddl, postgresColumn{name: "amount", udt: "numeric", isNullable: "YES"})
+	assert.Equal(t, column, "amount NUMERIC")
+
+	column = exportPostgresColumn(ddl, postgresColumn{name: "age", udt: "int4", precision: valid(32), scale: valid(0), isNullable: "YES"})
+	assert.Equal(t, column, "age INT4")
+
+	column = exportPostgresColumn(ddl, postgresColumn{name: "created_at", udt: "timestamptz", datetimePrecision: valid(3), isNullable: "YES"})
+	assert.Equal(t, column, "created_at TIMESTAMPTZ(3)")
+
+	column = exportPostgresColumn(ddl, postgresColumn{
+		name:       "id",
+		udt:        "int8",
+		precision:  valid(64),
+		scale:      valid(0),
+		isNullable: "NO",
+		def:        sql.NullString{String: "nextval('user_id_seq'::regclass)", Valid: true},
+	})
+	assert.Equal(t, column, "id BIGSERIAL NOT NULL")
+
+	column = exportPostgresColumn(ddl, postgresColumn{
+		name:       "id",
+		udt:        "int4",
+		isNullable: "NO",
+		isIdentity: sql.NullString{String: "YES", Valid: true},
+		generation: sql.NullString{String: "BY DEFAULT", Valid: true},
+	})
+	assert.Equal(t, column, "id INT4 GENERATED BY DEFAULT AS IDENTITY NOT NULL")
+}
+
+func TestExportMysqlColumn(t *testing.T) {
+	ddl := NewBuilder("mysql")
+	null := sql.NullString{}
+
+	column := exportMysqlColumn(ddl, "id", "int", "NO", null, sql.NullString{String: "auto_increment", Valid: true})
+	assert.Equal(t, column, "id INT NOT NULL AUTO_INCREMENT")
+
+	column = exportMysqlColumn(ddl, "name", "varchar(50)", "NO", sql.NullString{String: "it's", Valid: true}, null)
+	assert.Equal(t, column, "name VARCHAR(50) NOT NULL DEFAULT 'it''s'")
+
+	column = exportMysqlColumn(ddl, "created_at", "datetime", "YES", sql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, sql.NullString{String: "DEFAULT_GENERATED", Valid: true})
+	assert.Equal(t, column, "created_at DATETIME DEFAULT CURRENT_TIMESTAMP")
+}
@@ -1,20 +1,53 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // MysqlAdapter is a type of adapter that can be used with mysql driver
 type MysqlAdapter struct {
 	escaping bool
+	version  string
+}
+
+// minimum mysql versions of features
+var mysqlFeatures = map[Feature]string{
+	FeatureCTE:               "8.0",
+	FeatureLateral:           "8.0.14",
+	FeatureWindowFunctions:   "8.0",
+	FeatureRollup:            "4.1",
+	FeatureFullTextIndex:     "5.6",
+	FeatureSchemas:           "5.0",
+	FeaturePrivileges:        "4.1",
+	FeatureAlterColumn:       "4.1",
+	FeatureRenameIndex:       "5.7",
+	FeatureLoadData:          "3.22",
+	FeatureInformationSchema: "5.0",
+	FeatureUUID:              "8.0",
 }
 
 // Escape wraps the string with escape characters of the adapter
 func (a *MysqlAdapter) Escape(str string) string {
 	if a.escaping {
-		return fmt.Sprintf("`%s`", str)
+		return a.QuoteIdentifier(str)
 	}
 	return str
 }
 
+// QuoteIdentifier wraps the identifier with quote characters of the adapter regardless of escaping
+func (a *MysqlAdapter) QuoteIdentifier(str string) string {
+	return fmt.Sprintf("`%s`", strings.Replace(str, "`", "``", -1))
+}
+
+// QuoteLiteral wraps the string with single quotes escaping single quotes by doubling them
+// Backslashes are doubled as well, otherwise a trailing backslash escapes the closing quote
+// unless NO_BACKSLASH_ESCAPES sql mode is enabled
+func (a *MysqlAdapter) QuoteLiteral(str string) string {
+	str = strings.Replace(str, "\\", "\\\\", -1)
+	return fmt.Sprintf("'%s'", strings.Replace(str, "'", "''", -1))
+}
+
 // EscapeAll wraps all elements of string array
 func (a *MysqlAdapter) EscapeAll(strings []string) []string {
 	return escapeAll(a, strings[0:])
@@ -54,6 +87,21 @@ func (a *MysqlAdapter) SupportsInlinePrimaryKey() bool { return false }
 // SupportsUnsigned returns whether driver supports unsigned type mappings or not
 func (a *MysqlAdapter) SupportsUnsigned() bool { return true }
 
+// SupportsFeature returns whether driver supports the feature or not considering the version
+func (a *MysqlAdapter) SupportsFeature(f Feature) bool {
+	return supportsFeature(mysqlFeatures, a.version, f)
+}
+
+// Version returns the database version of adapter
+func (a *MysqlAdapter) Version() string {
+	return a.version
+}
+
+// SetVersion sets the database version of adapter
+func (a *MysqlAdapter) SetVersion(version string) {
+	a.version = version
+}
+
 // Driver returns the current driver of adapter
 func (a *MysqlAdapter) Driver() string {
 	return "mysql"
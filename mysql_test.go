@@ -124,6 +124,33 @@ func (suite *MysqlTestSuite) TestMysql() {
 	assert.Nil(suite.T(), suite.session.Metadata().DropAll())
 }
 
+func (suite *MysqlTestSuite) TestMysqlExportSchema() {
+	db := suite.session.Engine().DB().DB
+
+	_, err := db.Exec(`CREATE TABLE export_item (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(50) NOT NULL DEFAULT 'it''s',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		price DECIMAL(10,2),
+		CONSTRAINT u_export_item_name UNIQUE (name)
+	)`)
+	assert.Nil(suite.T(), err)
+
+	schema, err := suite.session.Builder().ExportSchema(db)
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), schema, "CREATE TABLE `export_item`(")
+	assert.Contains(suite.T(), schema, "\t`id` INT")
+	assert.Contains(suite.T(), schema, "NOT NULL AUTO_INCREMENT,")
+	assert.Contains(suite.T(), schema, "\t`name` VARCHAR(50) NOT NULL DEFAULT 'it''s',")
+	assert.Contains(suite.T(), schema, "\t`created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,")
+	assert.Contains(suite.T(), schema, "\t`price` DECIMAL(10,2),")
+	assert.Contains(suite.T(), schema, "\tPRIMARY KEY (`id`),")
+	assert.Contains(suite.T(), schema, "\tCONSTRAINT `u_export_item_name` UNIQUE(`name`)\n);")
+
+	_, err = db.Exec("DROP TABLE export_item")
+	assert.Nil(suite.T(), err)
+}
+
 func TestMysqlTestSuite(t *testing.T) {
 	suite.Run(t, new(MysqlTestSuite))
 }
@@ -1,21 +1,91 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // PostgresAdapter is a type of adapter that can be used with postgres driver
 type PostgresAdapter struct {
 	bindingIndex int
 	escaping     bool
+	version      string
+}
+
+// minimum postgres versions of features
+var postgresFeatures = map[Feature]string{
+	FeatureReturning:           "8.2",
+	FeatureCTE:                 "8.4",
+	FeatureMaterializedCTE:     "12",
+	FeatureWritableCTE:         "9.1",
+	FeatureLateral:             "9.3",
+	FeatureWindowFunctions:     "8.4",
+	FeatureFullOuterJoin:       "7.1",
+	FeatureGroupingSets:        "9.5",
+	FeatureRollup:              "9.5",
+	FeatureCube:                "9.5",
+	FeatureFilter:              "9.4",
+	FeatureILike:               "7.1",
+	FeatureAny:                 "7.4",
+	FeatureTablesample:         "9.5",
+	FeatureNullsOrdering:       "8.3",
+	FeatureFullTextIndex:       "8.3",
+	FeatureIndexInclude:        "11",
+	FeatureIndexConcurrently:   "8.2",
+	FeatureMaintenance:         "9.0",
+	FeatureReindexConcurrently: "12",
+	FeatureSessionConfig:       "7.3",
+	FeatureSchemas:             "7.3",
+	FeaturePrivileges:          "7.1",
+	FeatureRoles:               "8.1",
+	FeatureComments:            "7.1",
+	FeatureAlterColumn:         "7.1",
+	FeatureRenameIndex:         "8.0",
+	FeatureMaterializedView:    "9.3",
+	FeatureFunctions:           "8.0",
+	FeatureTriggers:            "7.3",
+	FeatureExecuteFunction:     "11",
+	FeatureListenNotify:        "9.0",
+	FeatureLockTable:           "7.1",
+	FeatureSetConstraints:      "7.3",
+	FeatureDo:                  "9.0",
+	FeaturePrepare:             "7.3",
+	FeatureRowLevelSecurity:    "9.5",
+	FeatureInheritance:         "8.2",
+	FeaturePartitioning:        "10",
+	FeatureUnlogged:            "9.5",
+	FeatureExtensions:          "9.1",
+	FeatureCollations:          "9.1",
+	FeatureLogicalReplication:  "10",
+	FeatureInformationSchema:   "7.4",
+	FeatureUUID:                "8.3",
+	FeatureGenRandomUUID:       "13",
+	FeatureJSONB:               "9.4",
+	FeatureArrays:              "7.4",
+	FeatureSpatial:             "9.1",
+	FeatureVector:              "11",
+	FeatureIdentityColumns:     "10",
+	FeaturePolicyType:          "10",
 }
 
 // Escape wraps the string with escape characters of the adapter
 func (a *PostgresAdapter) Escape(str string) string {
 	if a.escaping {
-		return fmt.Sprintf("\"%s\"", str)
+		return a.QuoteIdentifier(str)
 	}
 	return str
 }
 
+// QuoteIdentifier wraps the identifier with quote characters of the adapter regardless of escaping
+func (a *PostgresAdapter) QuoteIdentifier(str string) string {
+	return fmt.Sprintf("\"%s\"", strings.Replace(str, "\"", "\"\"", -1))
+}
+
+// QuoteLiteral wraps the string with single quotes escaping single quotes by doubling them
+func (a *PostgresAdapter) QuoteLiteral(str string) string {
+	return fmt.Sprintf("'%s'", strings.Replace(str, "'", "''", -1))
+}
+
 // EscapeAll wraps all elements of string array
 func (a *PostgresAdapter) EscapeAll(strings []string) []string {
 	return escapeAll(a, strings[0:])
@@ -56,6 +126,21 @@ func (a *PostgresAdapter) SupportsInlinePrimaryKey() bool { return true }
 // SupportsUnsigned returns whether driver supports unsigned type mappings or not
 func (a *PostgresAdapter) SupportsUnsigned() bool { return false }
 
+// SupportsFeature returns whether driver supports the feature or not considering the version
+func (a *PostgresAdapter) SupportsFeature(f Feature) bool {
+	return supportsFeature(postgresFeatures, a.version, f)
+}
+
+// Version returns the database version of adapter
+func (a *PostgresAdapter) Version() string {
+	return a.version
+}
+
+// SetVersion sets the database version of adapter
+func (a *PostgresAdapter) SetVersion(version string) {
+	a.version = version
+}
+
 // Driver returns the current driver of adapter
 func (a *PostgresAdapter) Driver() string {
 	return "postgres"
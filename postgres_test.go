@@ -104,7 +104,8 @@ func (suite *PostgresTestSuite) TestPostgres() {
 
 	// find user using QueryRow()
 	query = suite.session.Find(&User{ID: "cf28d117-a12d-4b75-acd8-73a7d3cbb15f"}).Query()
-	row := suite.session.Engine().QueryRow(query)
+	row, err := suite.session.Engine().QueryRow(query)
+	assert.Nil(suite.T(), err)
 	assert.NotNil(suite.T(), row)
 
 	// find user using Query()
@@ -168,6 +169,43 @@ func (suite *PostgresTestSuite) TestPostgres() {
 	assert.Nil(suite.T(), suite.session.Metadata().DropAll())
 }
 
+func (suite *PostgresTestSuite) TestPostgresExportSchema() {
+	db := suite.session.Engine().DB().DB
+
+	_, err := db.Exec("CREATE TYPE export_mood AS ENUM ('ok', 'sad')")
+	assert.Nil(suite.T(), err)
+	_, err = db.Exec(`CREATE TABLE export_item (
+		id SERIAL PRIMARY KEY,
+		code INT GENERATED ALWAYS AS IDENTITY,
+		tags TEXT[] NOT NULL,
+		mood export_mood,
+		name VARCHAR(50) NOT NULL DEFAULT 'x',
+		price NUMERIC(10,2),
+		created_at TIMESTAMP(3),
+		CONSTRAINT u_export_item_name UNIQUE (name)
+	)`)
+	assert.Nil(suite.T(), err)
+
+	schema, err := suite.session.Builder().ExportSchema(db)
+	assert.Nil(suite.T(), err)
+	assert.Contains(suite.T(), schema, "CREATE TABLE \"export_item\"(\n"+
+		"\t\"id\" SERIAL NOT NULL,\n"+
+		"\t\"code\" INT4 GENERATED ALWAYS AS IDENTITY NOT NULL,\n"+
+		"\t\"tags\" TEXT[] NOT NULL,\n"+
+		"\t\"mood\" EXPORT_MOOD,\n"+
+		"\t\"name\" VARCHAR(50) NOT NULL DEFAULT 'x'::character varying,\n"+
+		"\t\"price\" NUMERIC(10,2),\n"+
+		"\t\"created_at\" TIMESTAMP(3),\n"+
+		"\tPRIMARY KEY (\"id\"),\n"+
+		"\tCONSTRAINT \"u_export_item_name\" UNIQUE(\"name\")\n"+
+		");")
+
+	_, err = db.Exec("DROP TABLE export_item")
+	assert.Nil(suite.T(), err)
+	_, err = db.Exec("DROP TYPE export_mood")
+	assert.Nil(suite.T(), err)
+}
+
 func TestPostgresTestSuite(t *testing.T) {
 	suite.Run(t, new(PostgresTestSuite))
 }
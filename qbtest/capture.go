@@ -0,0 +1,78 @@
+// Package qbtest provides helpers for unit testing code that builds queries with qb without a database
+package qbtest
+
+import (
+	"github.com/aacanakin/qb"
+	"reflect"
+	"testing"
+)
+
+// NewTestBuilder generates a builder that records every query returned by Query() into a QueryCapture
+func NewTestBuilder(driver string) (*qb.Builder, *QueryCapture) {
+	capture := &QueryCapture{queries: []*qb.Query{}}
+	b := qb.NewBuilder(driver)
+	b.SetQueryHook(capture.add)
+	return b, capture
+}
+
+// QueryCapture is the recorder of the queries built by a test builder
+type QueryCapture struct {
+	queries []*qb.Query
+}
+
+func (c *QueryCapture) add(query *qb.Query) {
+	c.queries = append(c.queries, query)
+}
+
+// Queries returns all captured queries in the order they are built
+func (c *QueryCapture) Queries() []*qb.Query {
+	return c.queries
+}
+
+// last returns the most recently captured query, failing the test if there is none
+func (c *QueryCapture) last(t testing.TB) *qb.Query {
+	t.Helper()
+	if len(c.queries) == 0 {
+		t.Fatalf("No query is captured")
+	}
+	return c.queries[len(c.queries)-1]
+}
+
+// AssertSQL asserts the sql of the most recently captured query
+func (c *QueryCapture) AssertSQL(t testing.TB, expected string) {
+	t.Helper()
+	if sql := c.last(t).SQL(); sql != expected {
+		t.Errorf("Expected sql %q, got %q", expected, sql)
+	}
+}
+
+// AssertBindings asserts the bindings of the most recently captured query
+func (c *QueryCapture) AssertBindings(t testing.TB, expected []interface{}) {
+	t.Helper()
+	if bindings := c.last(t).Bindings(); !reflect.DeepEqual(bindings, expected) {
+		t.Errorf("Expected bindings %v, got %v", expected, bindings)
+	}
+}
+
+// AssertNthQuery asserts the sql and the bindings of the nth captured query where n is 0 based
+func (c *QueryCapture) AssertNthQuery(t testing.TB, n int, expectedSQL string, expectedBindings []interface{}) {
+	t.Helper()
+	if n < 0 || n >= len(c.queries) {
+		t.Fatalf("Query %d is not captured, %d queries are captured", n, len(c.queries))
+	}
+	query := c.queries[n]
+	if sql := query.SQL(); sql != expectedSQL {
+		t.Errorf("Expected sql %q of query %d, got %q", expectedSQL, n, sql)
+	}
+	if bindings := query.Bindings(); !reflect.DeepEqual(bindings, expectedBindings) {
+		t.Errorf("Expected bindings %v of query %d, got %v", expectedBindings, n, bindings)
+	}
+}
+
+// AssertQueryCount asserts the number of captured queries
+func (c *QueryCapture) AssertQueryCount(t testing.TB, n int) {
+	t.Helper()
+	if len(c.queries) != n {
+		t.Errorf("Expected %d queries, got %d", n, len(c.queries))
+	}
+}
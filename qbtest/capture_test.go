@@ -0,0 +1,35 @@
+package qbtest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQueryCapture(t *testing.T) {
+	b, capture := NewTestBuilder("postgres")
+	assert.Equal(t, len(capture.Queries()), 0)
+
+	b.Select("id").From("user").Where(b.Eq("email", "a@b.com")).Query()
+
+	assert.Equal(t, len(capture.Queries()), 1)
+	capture.AssertSQL(t, "SELECT id\nFROM user\nWHERE email = $1;")
+	capture.AssertBindings(t, []interface{}{"a@b.com"})
+
+	b.Delete("user").Query()
+
+	assert.Equal(t, len(capture.Queries()), 2)
+	capture.AssertSQL(t, "DELETE FROM user;")
+	capture.AssertBindings(t, []interface{}{})
+}
+
+func TestQueryCaptureNthQuery(t *testing.T) {
+	b, capture := NewTestBuilder("mysql")
+	capture.AssertQueryCount(t, 0)
+
+	b.Select("id").From("user").Limit(0, 20).Query()
+	b.Select("COUNT(*)").From("user").Where(b.Eq("active", true)).Query()
+
+	capture.AssertQueryCount(t, 2)
+	capture.AssertNthQuery(t, 0, "SELECT id\nFROM user\nLIMIT 20 OFFSET 0;", []interface{}{})
+	capture.AssertNthQuery(t, 1, "SELECT COUNT(*)\nFROM user\nWHERE active = ?;", []interface{}{true})
+}
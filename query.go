@@ -2,6 +2,7 @@ package qb
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -12,6 +13,7 @@ func NewQuery() *Query {
 	return &Query{
 		clauses:      []string{},
 		bindings:     []interface{}{},
+		errors:       []error{},
 		delimiter:    defaultDelimiter,
 		bindingIndex: 0,
 	}
@@ -21,8 +23,11 @@ func NewQuery() *Query {
 type Query struct {
 	clauses      []string
 	bindings     []interface{}
+	errors       []error
 	delimiter    string
 	bindingIndex int
+	// nonTransactional is set for queries that can't run inside a transaction block
+	nonTransactional bool
 }
 
 // SetDelimiter sets the delimiter of query
@@ -42,6 +47,11 @@ func (q *Query) AddBinding(bindings ...interface{}) {
 	}
 }
 
+// AddError appends a new error to current query
+func (q *Query) AddError(err error) {
+	q.errors = append(q.errors, err)
+}
+
 // Clauses returns all clauses of current query
 func (q *Query) Clauses() []string {
 	return q.clauses
@@ -52,6 +62,35 @@ func (q *Query) Bindings() []interface{} {
 	return q.bindings
 }
 
+// BindingAt returns the binding at index i and false if i is out of range
+func (q *Query) BindingAt(i int) (interface{}, bool) {
+	if i < 0 || i >= len(q.bindings) {
+		return nil, false
+	}
+	return q.bindings[i], true
+}
+
+// ContainsBinding returns true if v is one of the bindings of current query
+func (q *Query) ContainsBinding(v interface{}) bool {
+	for _, b := range q.bindings {
+		if reflect.DeepEqual(b, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns all errors of current query
+func (q *Query) Errors() []error {
+	return q.errors
+}
+
+// Transactional returns false if the query can't be executed inside a transaction block
+// such as "create index concurrently"
+func (q *Query) Transactional() bool {
+	return !q.nonTransactional
+}
+
 // SQL returns the query struct sql statement
 func (q *Query) SQL() string {
 	if len(q.clauses) > 0 {
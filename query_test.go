@@ -1,6 +1,7 @@
 package qb
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -16,6 +17,10 @@ func TestQuery(t *testing.T) {
 	assert.Equal(t, query.Clauses(), []string{"SELECT name", "FROM user", "WHERE id = ?"})
 	assert.Equal(t, query.Bindings(), []interface{}{5})
 	assert.Equal(t, query.SQL(), "SELECT name\nFROM user\nWHERE id = ?;")
+	assert.Equal(t, query.Errors(), []error{})
+
+	query.AddError(errors.New("invalid clause"))
+	assert.Equal(t, query.Errors(), []error{errors.New("invalid clause")})
 }
 
 func TestQueryWithDelimiter(t *testing.T) {
@@ -35,3 +40,30 @@ func TestQueryWithDelimiter(t *testing.T) {
 	assert.Equal(t, query.Bindings(), []interface{}{5})
 	assert.Equal(t, query.SQL(), "SELECT name FROM user WHERE id = ?;")
 }
+
+func TestQueryBindingAt(t *testing.T) {
+	query := NewQuery()
+	query.AddBinding(5, "a@b.com")
+
+	binding, ok := query.BindingAt(1)
+	assert.True(t, ok)
+	assert.Equal(t, binding, "a@b.com")
+
+	binding, ok = query.BindingAt(2)
+	assert.False(t, ok)
+	assert.Nil(t, binding)
+
+	_, ok = query.BindingAt(-1)
+	assert.False(t, ok)
+}
+
+func TestQueryContainsBinding(t *testing.T) {
+	query := NewQuery()
+	query.AddBinding(5, "a@b.com", []int{1, 2})
+
+	assert.True(t, query.ContainsBinding(5))
+	assert.True(t, query.ContainsBinding("a@b.com"))
+	assert.True(t, query.ContainsBinding([]int{1, 2}))
+	assert.False(t, query.ContainsBinding(int64(5)))
+	assert.False(t, query.ContainsBinding("c@d.com"))
+}
@@ -3,6 +3,7 @@ package qb
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 )
@@ -33,11 +34,22 @@ type Session struct {
 	tx       *sql.Tx
 	builder  *Builder
 	mutex    *sync.Mutex
+	errs     []error
 }
 
 func (s *Session) add(query *Query) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	if err := queryError(query); err != nil {
+		s.errs = append(s.errs, err)
+		return
+	}
+	if !query.Transactional() {
+		err := fmt.Errorf("Query can't be executed inside a transaction: %s", query.SQL())
+		query.AddError(err)
+		s.errs = append(s.errs, err)
+		return
+	}
 	var err error
 	if s.tx == nil {
 		s.queries = []*Query{}
@@ -118,12 +130,25 @@ func (s *Session) AddAll(models ...interface{}) {
 }
 
 // Commit commits the current transaction with queries
+// It returns the error of the first query that is rejected by the session without committing
 func (s *Session) Commit() error {
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = []error{}
+		if s.tx != nil {
+			s.tx.Rollback()
+		}
+		s.tx = nil
+		s.queries = []*Query{}
+		return err
+	}
+
 	for _, q := range s.queries {
 		_, err := s.tx.Exec(q.SQL(), q.Bindings()...)
 		if err != nil {
 			s.tx = nil
 			s.queries = []*Query{}
+			s.errs = []error{}
 			return err
 		}
 	}
@@ -131,13 +156,19 @@ func (s *Session) Commit() error {
 	err := s.tx.Commit()
 	s.tx = nil
 	s.queries = []*Query{}
+	s.errs = []error{}
 	return err
 }
 
 // Rollback rollbacks the current transaction
+// It also discards the queries and the errors of the rejected queries of the session
 func (s *Session) Rollback() error {
+	s.errs = []error{}
+	s.queries = []*Query{}
 	if s.tx != nil {
-		return s.tx.Rollback()
+		err := s.tx.Rollback()
+		s.tx = nil
+		return err
 	}
 
 	return errors.New("Current transaction is nil")
@@ -26,6 +26,54 @@ func TestSessionCommitError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestSessionRollbackRejectedQuery(t *testing.T) {
+	session, err := New("postgres", "user=postgres dbname=qb_test sslmode=disable")
+	assert.Nil(t, err)
+	defer session.Close()
+
+	session.AddQuery(session.Builder().Select("1").Query())
+	session.AddQuery(session.Builder().CreateIndexConcurrently("index_user_email", "user", "email").Query())
+
+	err = session.Rollback()
+	assert.Nil(t, err)
+
+	session.AddQuery(session.Builder().Select("1").Query())
+	err = session.Commit()
+	assert.Nil(t, err)
+}
+
+func TestSessionNonTransactionalReindex(t *testing.T) {
+	session, err := New("postgres", "user=postgres dbname=qb_test sslmode=disable")
+	assert.Nil(t, err)
+	defer session.Close()
+
+	query := session.Builder().Reindex("public", ReindexSchema).Query()
+	session.AddQuery(query)
+	assert.Equal(t, len(query.Errors()), 1)
+
+	err = session.Commit()
+	assert.NotNil(t, err)
+
+	query = session.Builder().Reindex("user", ReindexConcurrently).Query()
+	session.AddQuery(query)
+	assert.Equal(t, len(query.Errors()), 1)
+
+	err = session.Commit()
+	assert.NotNil(t, err)
+}
+
+func TestSessionQueryErrors(t *testing.T) {
+	session, err := New("postgres", "user=postgres dbname=qb_test sslmode=disable")
+	assert.Nil(t, err)
+	defer session.Close()
+
+	query := NewBuilder("mysql").Delete("user").Returning("id").Query()
+	session.AddQuery(query)
+
+	err = session.Commit()
+	assert.Equal(t, err, query.Errors()[0])
+}
+
 func TestSessionAddError(t *testing.T) {
 	session, err := New("postgres", "user=postgres dbname=qb_test sslmode=disable")
 	assert.Nil(t, err)
@@ -89,3 +137,16 @@ func TestSessionWrappings(t *testing.T) {
 
 	assert.NotNil(t, session.Builder())
 }
+
+func TestSessionNonTransactionalQuery(t *testing.T) {
+	session, err := New("postgres", "user=postgres dbname=qb_test sslmode=disable")
+	assert.Nil(t, err)
+	defer session.Close()
+
+	query := session.Builder().CreateIndexConcurrently("index_user_email", "user", "email").Query()
+	session.AddQuery(query)
+	assert.Equal(t, len(query.Errors()), 1)
+
+	err = session.Commit()
+	assert.NotNil(t, err)
+}
@@ -1,20 +1,44 @@
 package qb
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // SqliteAdapter is a type of adapter that can be used with sqlite driver
 type SqliteAdapter struct {
 	escaping bool
+	version  string
+}
+
+// minimum sqlite versions of features
+var sqliteFeatures = map[Feature]string{
+	FeatureReturning:       "3.35",
+	FeatureCTE:             "3.8.3",
+	FeatureWindowFunctions: "3.25",
+	FeatureFullOuterJoin:   "3.39",
+	FeatureFilter:          "3.30",
+	FeatureNullsOrdering:   "3.30",
 }
 
 // Escape wraps the string with escape characters of the adapter
 func (a *SqliteAdapter) Escape(str string) string {
 	if a.escaping {
-		return fmt.Sprintf("`%s`", str)
+		return a.QuoteIdentifier(str)
 	}
 	return str
 }
 
+// QuoteIdentifier wraps the identifier with quote characters of the adapter regardless of escaping
+func (a *SqliteAdapter) QuoteIdentifier(str string) string {
+	return fmt.Sprintf("`%s`", strings.Replace(str, "`", "``", -1))
+}
+
+// QuoteLiteral wraps the string with single quotes escaping single quotes by doubling them
+func (a *SqliteAdapter) QuoteLiteral(str string) string {
+	return fmt.Sprintf("'%s'", strings.Replace(str, "'", "''", -1))
+}
+
 // EscapeAll wraps all elements of string array
 func (a *SqliteAdapter) EscapeAll(strings []string) []string {
 	return escapeAll(a, strings[0:])
@@ -54,6 +78,21 @@ func (a *SqliteAdapter) SupportsInlinePrimaryKey() bool { return true }
 // SupportsUnsigned returns whether driver supports unsigned type mappings or not
 func (a *SqliteAdapter) SupportsUnsigned() bool { return false }
 
+// SupportsFeature returns whether driver supports the feature or not considering the version
+func (a *SqliteAdapter) SupportsFeature(f Feature) bool {
+	return supportsFeature(sqliteFeatures, a.version, f)
+}
+
+// Version returns the database version of adapter
+func (a *SqliteAdapter) Version() string {
+	return a.version
+}
+
+// SetVersion sets the database version of adapter
+func (a *SqliteAdapter) SetVersion(version string) {
+	a.version = version
+}
+
 // Driver returns the current driver of adapter
 func (a *SqliteAdapter) Driver() string {
 	return "sqlite3"
@@ -0,0 +1,75 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rows starts a "rows" window frame specification
+func Rows() *WindowFrame {
+	return &WindowFrame{unit: "ROWS"}
+}
+
+// Range starts a "range" window frame specification
+func Range() *WindowFrame {
+	return &WindowFrame{unit: "RANGE"}
+}
+
+// Groups starts a "groups" window frame specification
+func Groups() *WindowFrame {
+	return &WindowFrame{unit: "GROUPS"}
+}
+
+// WindowFrame is the builder for frame clauses of window functions
+// such as "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW"
+type WindowFrame struct {
+	unit  string
+	start string
+	end   string
+}
+
+// BetweenUnboundedPreceding sets the frame start to "unbounded preceding"
+func (f *WindowFrame) BetweenUnboundedPreceding() *WindowFrame {
+	f.start = "UNBOUNDED PRECEDING"
+	return f
+}
+
+// AndCurrentRow sets the frame end to "current row"
+func (f *WindowFrame) AndCurrentRow() *WindowFrame {
+	f.end = "CURRENT ROW"
+	return f
+}
+
+// AndUnboundedFollowing sets the frame end to "unbounded following"
+func (f *WindowFrame) AndUnboundedFollowing() *WindowFrame {
+	f.end = "UNBOUNDED FOLLOWING"
+	return f
+}
+
+// Preceding sets the frame start to "%d preceding" if it is not set yet, otherwise sets the frame end
+func (f *WindowFrame) Preceding(n int) *WindowFrame {
+	f.bound(fmt.Sprintf("%d PRECEDING", n))
+	return f
+}
+
+// Following sets the frame start to "%d following" if it is not set yet, otherwise sets the frame end
+func (f *WindowFrame) Following(n int) *WindowFrame {
+	f.bound(fmt.Sprintf("%d FOLLOWING", n))
+	return f
+}
+
+func (f *WindowFrame) bound(bound string) {
+	if f.start == "" {
+		f.start = bound
+	} else {
+		f.end = bound
+	}
+}
+
+// SQL returns the frame clause as an sql statement
+func (f *WindowFrame) SQL() string {
+	if f.start == "" || f.end == "" {
+		return strings.TrimSpace(fmt.Sprintf("%s %s%s", f.unit, f.start, f.end))
+	}
+	return fmt.Sprintf("%s BETWEEN %s AND %s", f.unit, f.start, f.end)
+}
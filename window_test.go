@@ -0,0 +1,14 @@
+package qb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWindowFrame(t *testing.T) {
+	assert.Equal(t, Rows().BetweenUnboundedPreceding().AndCurrentRow().SQL(), "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW")
+	assert.Equal(t, Range().BetweenUnboundedPreceding().AndUnboundedFollowing().SQL(), "RANGE BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING")
+	assert.Equal(t, Groups().Preceding(2).Following(3).SQL(), "GROUPS BETWEEN 2 PRECEDING AND 3 FOLLOWING")
+	assert.Equal(t, Rows().Preceding(5).AndCurrentRow().SQL(), "ROWS BETWEEN 5 PRECEDING AND CURRENT ROW")
+	assert.Equal(t, Rows().Preceding(1).SQL(), "ROWS 1 PRECEDING")
+}